@@ -0,0 +1,144 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"net/url"
+	"strconv"
+)
+
+// AdminAPIKey represents an organization admin API key.
+type AdminAPIKey struct {
+	ID            string           `json:"id"`
+	Object        string           `json:"object"`
+	Name          string           `json:"name"`
+	RedactedValue string           `json:"redacted_value"`
+	Value         string           `json:"value,omitempty"` // Only returned when the key is created
+	CreatedAt     int64            `json:"created_at"`
+	LastUsedAt    *int64           `json:"last_used_at"`
+	Owner         AdminAPIKeyOwner `json:"owner"`
+}
+
+// AdminAPIKeyOwner represents the user or service account that owns an admin API key.
+type AdminAPIKeyOwner struct {
+	Type      string `json:"type"`
+	Object    string `json:"object"`
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	CreatedAt int64  `json:"created_at"`
+	Role      string `json:"role"`
+}
+
+// ListAdminAPIKeyResponse represents the response from the list admin API keys API.
+type ListAdminAPIKeyResponse struct {
+	Object  string        `json:"object"`
+	Data    []AdminAPIKey `json:"data"`
+	FirstID string        `json:"first_id"`
+	LastID  string        `json:"last_id"`
+	HasMore bool          `json:"has_more"`
+}
+
+// DeletedAdminAPIKeyResponse represents the response from the delete admin API key API.
+type DeletedAdminAPIKeyResponse struct {
+	Object  string `json:"object"`
+	ID      string `json:"id"`
+	Deleted bool   `json:"deleted"`
+}
+
+// ListAdminAPIKeys retrieves all admin API keys of the organization.
+func (c *Client) ListAdminAPIKeys(ctx context.Context) (*[]AdminAPIKey, error) {
+	return collect(c.AdminAPIKeys(ctx))
+}
+
+// AdminAPIKeys returns an iterator over all admin API keys of the organization.
+func (c *Client) AdminAPIKeys(ctx context.Context) iter.Seq2[AdminAPIKey, error] {
+	const pageSize = 100
+	return paginate(func(after string) ([]AdminAPIKey, string, bool, error) {
+		resp, err := c.listAdminAPIKeys(ctx, after, pageSize)
+		if err != nil {
+			return nil, "", false, fmt.Errorf("get admin api key list: %w", err)
+		}
+		return resp.Data, resp.LastID, resp.HasMore, nil
+	})
+}
+
+// listAdminAPIKeys retrieves a page of admin API keys with pagination options.
+func (c *Client) listAdminAPIKeys(ctx context.Context, after string, limit int) (*ListAdminAPIKeyResponse, error) {
+	query := url.Values{}
+	if after != "" {
+		query.Set("after", after)
+	}
+	if limit > 0 {
+		query.Set("limit", strconv.Itoa(limit))
+	}
+
+	respBody, err := c.doRequest(ctx, "GET", "/admin_api_keys", query, nil)
+	if err != nil {
+		return nil, fmt.Errorf("execute request: %w", err)
+	}
+	var result ListAdminAPIKeyResponse
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		return nil, fmt.Errorf("unmarshal json: %w", err)
+	}
+	return &result, nil
+}
+
+// CreateAdminAPIKey creates a new admin API key. The secret is only available in the returned Value.
+func (c *Client) CreateAdminAPIKey(ctx context.Context, name string) (*AdminAPIKey, error) {
+	body := map[string]string{"name": name}
+	respBody, err := c.doRequest(ctx, "POST", "/admin_api_keys", nil, body)
+	if err != nil {
+		return nil, fmt.Errorf("create admin api key: %w", err)
+	}
+	var key AdminAPIKey
+	err = json.Unmarshal(respBody, &key)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal json: %w", err)
+	}
+	return &key, nil
+}
+
+// GetAdminAPIKey retrieves an admin API key by ID.
+func (c *Client) GetAdminAPIKey(ctx context.Context, keyID string) (*AdminAPIKey, error) {
+	respBody, err := c.doRequest(ctx, "GET", fmt.Sprintf("/admin_api_keys/%s", keyID), nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("get admin api key: %w", err)
+	}
+	var key AdminAPIKey
+	err = json.Unmarshal(respBody, &key)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal json: %w", err)
+	}
+	return &key, nil
+}
+
+// DeleteAdminAPIKey deletes an admin API key.
+func (c *Client) DeleteAdminAPIKey(ctx context.Context, keyID string) (*DeletedAdminAPIKeyResponse, error) {
+	respBody, err := c.doRequest(ctx, "DELETE", fmt.Sprintf("/admin_api_keys/%s", keyID), nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("delete admin api key: %w", err)
+	}
+	var result DeletedAdminAPIKeyResponse
+	err = json.Unmarshal(respBody, &result)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal json: %w", err)
+	}
+	return &result, nil
+}
+
+// RotateAdminAPIKey creates a replacement admin API key and then deletes the old one.
+// If the old key is the one this client authenticates with, the caller must switch to
+// the returned key before making further requests.
+func (c *Client) RotateAdminAPIKey(ctx context.Context, oldKeyID string, name string) (*AdminAPIKey, error) {
+	key, err := c.CreateAdminAPIKey(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("rotate admin api key: %w", err)
+	}
+	if _, err := c.DeleteAdminAPIKey(ctx, oldKeyID); err != nil {
+		// Return the new key as well so that its secret is not lost.
+		return key, fmt.Errorf("rotate admin api key: %w", err)
+	}
+	return key, nil
+}
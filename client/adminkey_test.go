@@ -0,0 +1,206 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestListAdminAPIKeys(t *testing.T) {
+	// Test data
+	keys := []AdminAPIKey{
+		{
+			ID:            "key_abc",
+			Object:        "organization.admin_api_key",
+			Name:          "Main Admin Key",
+			RedactedValue: "sk-admin...def",
+			CreatedAt:     1711471533,
+			Owner: AdminAPIKeyOwner{
+				Type:   "service_account",
+				Object: "organization.service_account",
+				ID:     "sa_456",
+				Name:   "My Service Account",
+				Role:   "member",
+			},
+		},
+	}
+	responseBody, _ := json.Marshal(ListAdminAPIKeyResponse{Object: "list", Data: keys, FirstID: "key_abc", LastID: "key_abc", HasMore: false})
+
+	// Create mock HTTP client
+	mockClient := &MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			if req.Method != "GET" {
+				t.Errorf("Expected method to be GET, got %s", req.Method)
+			}
+			if req.URL.Path != "/v1/organization/admin_api_keys" {
+				t.Errorf("Expected path to be /v1/organization/admin_api_keys, got %s", req.URL.Path)
+			}
+			return &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(strings.NewReader(string(responseBody))),
+			}, nil
+		},
+	}
+
+	// Create client
+	client := &Client{
+		APIKey:     "test-api-key",
+		HTTPClient: mockClient,
+		BaseURL:    "https://api.openai.com/v1/organization",
+	}
+
+	// Test ListAdminAPIKeys
+	result, err := client.ListAdminAPIKeys(context.Background())
+
+	// Verify result
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(*result, keys) {
+		t.Errorf("Expected admin api keys to be %+v, got %+v", keys, *result)
+	}
+}
+
+func TestCreateAdminAPIKey(t *testing.T) {
+	// Create mock HTTP client
+	mockClient := &MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			if req.Method != "POST" {
+				t.Errorf("Expected method to be POST, got %s", req.Method)
+			}
+			if req.URL.Path != "/v1/organization/admin_api_keys" {
+				t.Errorf("Expected path to be /v1/organization/admin_api_keys, got %s", req.URL.Path)
+			}
+			body, _ := io.ReadAll(req.Body)
+			if string(body) != `{"name":"New Admin Key"}` {
+				t.Errorf("Expected request body to be %s, got %s", `{"name":"New Admin Key"}`, string(body))
+			}
+			return &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(strings.NewReader(`{"object":"organization.admin_api_key","id":"key_xyz","name":"New Admin Key","redacted_value":"sk-admin...xyz","value":"sk-admin-1234abcd","created_at":1711471533}`)),
+			}, nil
+		},
+	}
+
+	// Create client
+	client := &Client{
+		APIKey:     "test-api-key",
+		HTTPClient: mockClient,
+		BaseURL:    "https://api.openai.com/v1/organization",
+	}
+
+	// Test CreateAdminAPIKey
+	key, err := client.CreateAdminAPIKey(context.Background(), "New Admin Key")
+
+	// Verify result
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if key.ID != "key_xyz" || key.Value != "sk-admin-1234abcd" {
+		t.Errorf("Expected key_xyz with value sk-admin-1234abcd, got %+v", key)
+	}
+}
+
+func TestGetAndDeleteAdminAPIKey(t *testing.T) {
+	// Create mock HTTP client
+	mockClient := &MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			if req.URL.Path != "/v1/organization/admin_api_keys/key_abc" {
+				t.Errorf("Expected path to be /v1/organization/admin_api_keys/key_abc, got %s", req.URL.Path)
+			}
+			body := `{"object":"organization.admin_api_key","id":"key_abc","name":"Main Admin Key","redacted_value":"sk-admin...def"}`
+			if req.Method == "DELETE" {
+				body = `{"object":"organization.admin_api_key.deleted","id":"key_abc","deleted":true}`
+			}
+			return &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(strings.NewReader(body)),
+			}, nil
+		},
+	}
+
+	// Create client
+	client := &Client{
+		APIKey:     "test-api-key",
+		HTTPClient: mockClient,
+		BaseURL:    "https://api.openai.com/v1/organization",
+	}
+
+	// Test GetAdminAPIKey
+	key, err := client.GetAdminAPIKey(context.Background(), "key_abc")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if key.RedactedValue != "sk-admin...def" {
+		t.Errorf("Expected redacted value to be 'sk-admin...def', got '%s'", key.RedactedValue)
+	}
+
+	// Test DeleteAdminAPIKey
+	deleted, err := client.DeleteAdminAPIKey(context.Background(), "key_abc")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !deleted.Deleted {
+		t.Error("Expected key to be deleted")
+	}
+}
+
+func TestRotateAdminAPIKey(t *testing.T) {
+	tests := []struct {
+		name        string
+		deleteError error
+		expectError bool
+	}{
+		{name: "Success", deleteError: nil, expectError: false},
+		{name: "Delete error keeps new key", deleteError: errors.New("http client error"), expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls []string
+			client := &Client{
+				APIKey: "test-api-key",
+				HTTPClient: &MockHTTPClient{
+					DoFunc: func(req *http.Request) (*http.Response, error) {
+						calls = append(calls, req.Method+" "+req.URL.Path)
+						if req.Method == "DELETE" {
+							if tt.deleteError != nil {
+								return nil, tt.deleteError
+							}
+							return &http.Response{
+								StatusCode: 200,
+								Body:       io.NopCloser(strings.NewReader(`{"id":"key_old","deleted":true}`)),
+							}, nil
+						}
+						return &http.Response{
+							StatusCode: 200,
+							Body:       io.NopCloser(strings.NewReader(`{"id":"key_new","value":"sk-admin-new"}`)),
+						}, nil
+					},
+				},
+				BaseURL: "https://api.openai.com/v1/organization",
+			}
+
+			key, err := client.RotateAdminAPIKey(context.Background(), "key_old", "rotated")
+
+			if (err != nil) != tt.expectError {
+				t.Errorf("Expected error to be %v, got %v", tt.expectError, err)
+			}
+			if key == nil || key.Value != "sk-admin-new" {
+				t.Errorf("Expected new key to be returned, got %+v", key)
+			}
+			expectedCalls := []string{
+				"POST /v1/organization/admin_api_keys",
+				"DELETE /v1/organization/admin_api_keys/key_old",
+			}
+			if !reflect.DeepEqual(calls, expectedCalls) {
+				t.Errorf("Expected calls to be %v, got %v", expectedCalls, calls)
+			}
+		})
+	}
+}
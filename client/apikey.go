@@ -0,0 +1,137 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"net/url"
+	"strconv"
+)
+
+// Owner types of a project API key.
+const (
+	OwnerTypeUser           = "user"
+	OwnerTypeServiceAccount = "service_account"
+)
+
+// ProjectAPIKey represents an API key that belongs to an OpenAI project.
+type ProjectAPIKey struct {
+	ID            string             `json:"id"`
+	Object        string             `json:"object"`
+	Name          string             `json:"name"`
+	RedactedValue string             `json:"redacted_value"`
+	CreatedAt     int64              `json:"created_at"`
+	LastUsedAt    *int64             `json:"last_used_at"`
+	Owner         ProjectAPIKeyOwner `json:"owner"`
+}
+
+// ProjectAPIKeyOwner represents the user or service account that owns a project API key.
+type ProjectAPIKeyOwner struct {
+	Type           string                     `json:"type"`
+	User           *APIKeyOwnerUser           `json:"user,omitempty"`
+	ServiceAccount *APIKeyOwnerServiceAccount `json:"service_account,omitempty"`
+}
+
+// APIKeyOwnerUser represents a user that owns an API key.
+type APIKeyOwnerUser struct {
+	ID      string `json:"id"`
+	Object  string `json:"object"`
+	Name    string `json:"name"`
+	Email   string `json:"email"`
+	Role    string `json:"role"`
+	AddedAt int64  `json:"added_at"`
+}
+
+// APIKeyOwnerServiceAccount represents a service account that owns an API key.
+type APIKeyOwnerServiceAccount struct {
+	ID        string `json:"id"`
+	Object    string `json:"object"`
+	Name      string `json:"name"`
+	Role      string `json:"role"`
+	CreatedAt int64  `json:"created_at"`
+}
+
+// ListProjectAPIKeyResponse represents the response from the list project API keys API.
+type ListProjectAPIKeyResponse struct {
+	Object  string          `json:"object"`
+	Data    []ProjectAPIKey `json:"data"`
+	FirstID string          `json:"first_id"`
+	LastID  string          `json:"last_id"`
+	HasMore bool            `json:"has_more"`
+}
+
+// DeletedProjectAPIKeyResponse represents the response from the delete project API key API.
+type DeletedProjectAPIKeyResponse struct {
+	Object  string `json:"object"`
+	ID      string `json:"id"`
+	Deleted bool   `json:"deleted"`
+}
+
+// ListProjectAPIKeys retrieves all API keys for a project.
+func (c *Client) ListProjectAPIKeys(ctx context.Context, projectID string) (*[]ProjectAPIKey, error) {
+	return collect(c.ProjectAPIKeys(ctx, projectID))
+}
+
+// ProjectAPIKeys returns an iterator over all API keys for a project.
+func (c *Client) ProjectAPIKeys(ctx context.Context, projectID string) iter.Seq2[ProjectAPIKey, error] {
+	const pageSize = 100
+	return paginate(func(after string) ([]ProjectAPIKey, string, bool, error) {
+		resp, err := c.listProjectAPIKeys(ctx, projectID, after, pageSize)
+		if err != nil {
+			return nil, "", false, fmt.Errorf("get project api key list: %w", err)
+		}
+		return resp.Data, resp.LastID, resp.HasMore, nil
+	})
+}
+
+// listProjectAPIKeys retrieves a page of project API keys with pagination options.
+func (c *Client) listProjectAPIKeys(ctx context.Context, projectID string, after string, limit int) (*ListProjectAPIKeyResponse, error) {
+	query := url.Values{}
+	if after != "" {
+		query.Set("after", after)
+	}
+	if limit > 0 {
+		query.Set("limit", strconv.Itoa(limit))
+	}
+
+	path := fmt.Sprintf("/projects/%s/api_keys", projectID)
+	respBody, err := c.doRequest(ctx, "GET", path, query, nil)
+	if err != nil {
+		return nil, fmt.Errorf("execute request: %w", err)
+	}
+	var result ListProjectAPIKeyResponse
+	err = json.Unmarshal(respBody, &result)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal json: %w", err)
+	}
+	return &result, nil
+}
+
+// GetProjectAPIKey retrieves a single API key from a project.
+func (c *Client) GetProjectAPIKey(ctx context.Context, projectID string, keyID string) (*ProjectAPIKey, error) {
+	respBody, err := c.doRequest(ctx, "GET", fmt.Sprintf("/projects/%s/api_keys/%s", projectID, keyID), nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("get project api key: %w", err)
+	}
+	var key ProjectAPIKey
+	err = json.Unmarshal(respBody, &key)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal json: %w", err)
+	}
+	return &key, nil
+}
+
+// DeleteProjectAPIKey removes an API key from a project.
+func (c *Client) DeleteProjectAPIKey(ctx context.Context, projectID string, keyID string) (*DeletedProjectAPIKeyResponse, error) {
+	respBody, err := c.doRequest(ctx, "DELETE", fmt.Sprintf("/projects/%s/api_keys/%s", projectID, keyID), nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("delete project api key: %w", err)
+	}
+	var result DeletedProjectAPIKeyResponse
+	err = json.Unmarshal(respBody, &result)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal json: %w", err)
+	}
+	return &result, nil
+}
@@ -0,0 +1,234 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestListProjectAPIKeys_Pagination(t *testing.T) {
+	// Test data
+	projectID := "proj_123"
+	lastUsedAt := int64(1711471534)
+
+	// First page data
+	firstPageKeys := []ProjectAPIKey{
+		{
+			ID:            "key_123",
+			Object:        "organization.project.api_key",
+			Name:          "user-key",
+			RedactedValue: "sk-abc...def",
+			CreatedAt:     1711471533,
+			LastUsedAt:    &lastUsedAt,
+			Owner: ProjectAPIKeyOwner{
+				Type: OwnerTypeUser,
+				User: &APIKeyOwnerUser{
+					ID:    "user_123",
+					Email: "user@example.com",
+				},
+			},
+		},
+	}
+	firstPageBody, _ := json.Marshal(ListProjectAPIKeyResponse{
+		Object:  "list",
+		Data:    firstPageKeys,
+		FirstID: "key_123",
+		LastID:  "key_123",
+		HasMore: true,
+	})
+
+	// Second page data
+	secondPageKeys := []ProjectAPIKey{
+		{
+			ID:            "key_456",
+			Object:        "organization.project.api_key",
+			Name:          "service-account-key",
+			RedactedValue: "sk-ghi...jkl",
+			CreatedAt:     1711471533,
+			Owner: ProjectAPIKeyOwner{
+				Type: OwnerTypeServiceAccount,
+				ServiceAccount: &APIKeyOwnerServiceAccount{
+					ID:   "svc_acct_123",
+					Name: "service-account",
+				},
+			},
+		},
+	}
+	secondPageBody, _ := json.Marshal(ListProjectAPIKeyResponse{
+		Object:  "list",
+		Data:    secondPageKeys,
+		FirstID: "key_456",
+		LastID:  "key_456",
+		HasMore: false,
+	})
+
+	// Create mock HTTP client
+	callCount := 0
+	mockClient := &MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			callCount++
+			if req.Method != "GET" {
+				t.Errorf("Expected method to be GET, got %s", req.Method)
+			}
+			if req.URL.Path != "/v1/organization/projects/"+projectID+"/api_keys" {
+				t.Errorf("Expected path to be /v1/organization/projects/%s/api_keys, got %s", projectID, req.URL.Path)
+			}
+			body := firstPageBody
+			if callCount == 1 {
+				if req.URL.Query().Get("after") != "" {
+					t.Errorf("Expected no 'after' query parameter on first call, got '%s'", req.URL.Query().Get("after"))
+				}
+			} else {
+				if req.URL.Query().Get("after") != "key_123" {
+					t.Errorf("Expected 'after' query parameter to be 'key_123' on second call, got '%s'", req.URL.Query().Get("after"))
+				}
+				body = secondPageBody
+			}
+			return &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(strings.NewReader(string(body))),
+			}, nil
+		},
+	}
+
+	// Create client
+	client := &Client{
+		APIKey:     "test-api-key",
+		HTTPClient: mockClient,
+		BaseURL:    "https://api.openai.com/v1/organization",
+	}
+
+	// Test ListProjectAPIKeys
+	keys, err := client.ListProjectAPIKeys(context.Background(), projectID)
+
+	// Verify result
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	expectedKeys := append(firstPageKeys, secondPageKeys...)
+	if !reflect.DeepEqual(*keys, expectedKeys) {
+		t.Errorf("Expected keys to be %+v, got %+v", expectedKeys, *keys)
+	}
+	if callCount != 2 {
+		t.Errorf("Expected 2 API calls, got %d", callCount)
+	}
+}
+
+func TestGetProjectAPIKey(t *testing.T) {
+	// Test data
+	projectID := "proj_123"
+	keyID := "key_123"
+	responseBody := `{
+		"object": "organization.project.api_key",
+		"redacted_value": "sk-abc...def",
+		"name": "My API Key",
+		"created_at": 1711471533,
+		"last_used_at": null,
+		"id": "key_123",
+		"owner": {
+			"type": "service_account",
+			"service_account": {
+				"object": "organization.project.service_account",
+				"id": "svc_acct_123",
+				"name": "user@example.com",
+				"role": "member",
+				"created_at": 1711471533
+			}
+		}
+	}`
+
+	// Create mock HTTP client
+	mockClient := &MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			if req.Method != "GET" {
+				t.Errorf("Expected method to be GET, got %s", req.Method)
+			}
+			expectedURL := "https://api.openai.com/v1/organization/projects/" + projectID + "/api_keys/" + keyID
+			if req.URL.String() != expectedURL {
+				t.Errorf("Expected URL to be %s, got %s", expectedURL, req.URL.String())
+			}
+			return &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(strings.NewReader(responseBody)),
+			}, nil
+		},
+	}
+
+	// Create client
+	client := &Client{
+		APIKey:     "test-api-key",
+		HTTPClient: mockClient,
+		BaseURL:    "https://api.openai.com/v1/organization",
+	}
+
+	// Test GetProjectAPIKey
+	key, err := client.GetProjectAPIKey(context.Background(), projectID, keyID)
+
+	// Verify result
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if key.ID != keyID || key.RedactedValue != "sk-abc...def" {
+		t.Errorf("Expected key %s with redacted value sk-abc...def, got %+v", keyID, key)
+	}
+	if key.LastUsedAt != nil {
+		t.Errorf("Expected nil LastUsedAt, got %v", *key.LastUsedAt)
+	}
+	if key.Owner.Type != OwnerTypeServiceAccount || key.Owner.ServiceAccount == nil || key.Owner.ServiceAccount.ID != "svc_acct_123" {
+		t.Errorf("Expected service account owner svc_acct_123, got %+v", key.Owner)
+	}
+	if key.Owner.User != nil {
+		t.Errorf("Expected nil user owner, got %+v", key.Owner.User)
+	}
+}
+
+func TestDeleteProjectAPIKey(t *testing.T) {
+	// Test data
+	projectID := "proj_123"
+	keyID := "key_123"
+	expectedResponse := DeletedProjectAPIKeyResponse{
+		Object:  "organization.project.api_key.deleted",
+		ID:      keyID,
+		Deleted: true,
+	}
+	responseBody, _ := json.Marshal(expectedResponse)
+
+	// Create mock HTTP client
+	mockClient := &MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			if req.Method != "DELETE" {
+				t.Errorf("Expected method to be DELETE, got %s", req.Method)
+			}
+			expectedURL := "https://api.openai.com/v1/organization/projects/" + projectID + "/api_keys/" + keyID
+			if req.URL.String() != expectedURL {
+				t.Errorf("Expected URL to be %s, got %s", expectedURL, req.URL.String())
+			}
+			return &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(strings.NewReader(string(responseBody))),
+			}, nil
+		},
+	}
+
+	// Create client
+	client := &Client{
+		APIKey:     "test-api-key",
+		HTTPClient: mockClient,
+		BaseURL:    "https://api.openai.com/v1/organization",
+	}
+
+	// Test DeleteProjectAPIKey
+	result, err := client.DeleteProjectAPIKey(context.Background(), projectID, keyID)
+
+	// Verify result
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(*result, expectedResponse) {
+		t.Errorf("Expected result to be %+v, got %+v", expectedResponse, *result)
+	}
+}
@@ -0,0 +1,170 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Audit log event types related to the resources this server manages.
+const (
+	AuditEventAPIKeyCreated         = "api_key.created"
+	AuditEventAPIKeyUpdated         = "api_key.updated"
+	AuditEventAPIKeyDeleted         = "api_key.deleted"
+	AuditEventServiceAccountCreated = "service_account.created"
+	AuditEventServiceAccountUpdated = "service_account.updated"
+	AuditEventServiceAccountDeleted = "service_account.deleted"
+	AuditEventProjectCreated        = "project.created"
+	AuditEventProjectUpdated        = "project.updated"
+	AuditEventProjectArchived       = "project.archived"
+)
+
+// AuditLogFilter holds the filters for an audit logs request. Zero values are not sent.
+type AuditLogFilter struct {
+	EventTypes      []string  // Only return these event types
+	ProjectIDs      []string  // Only return events for these projects
+	ActorIDs        []string  // Only return events performed by these users or API keys
+	ActorEmails     []string  // Only return events performed by users with these emails
+	ResourceIDs     []string  // Only return events for these resources
+	EffectiveAfter  time.Time // Only return events effective at or after this time
+	EffectiveBefore time.Time // Only return events effective before this time
+}
+
+// values encodes the filter as URL parameters.
+func (f AuditLogFilter) values() url.Values {
+	query := url.Values{}
+	for _, eventType := range f.EventTypes {
+		query.Add("event_types[]", eventType)
+	}
+	for _, id := range f.ProjectIDs {
+		query.Add("project_ids[]", id)
+	}
+	for _, id := range f.ActorIDs {
+		query.Add("actor_ids[]", id)
+	}
+	for _, email := range f.ActorEmails {
+		query.Add("actor_emails[]", email)
+	}
+	for _, id := range f.ResourceIDs {
+		query.Add("resource_ids[]", id)
+	}
+	if !f.EffectiveAfter.IsZero() {
+		query.Set("effective_at[gte]", strconv.FormatInt(f.EffectiveAfter.Unix(), 10))
+	}
+	if !f.EffectiveBefore.IsZero() {
+		query.Set("effective_at[lt]", strconv.FormatInt(f.EffectiveBefore.Unix(), 10))
+	}
+	return query
+}
+
+// AuditLog represents an event in the organization audit log.
+type AuditLog struct {
+	ID          string           `json:"id"`
+	Type        string           `json:"type"`
+	EffectiveAt int64            `json:"effective_at"`
+	Project     *AuditLogProject `json:"project,omitempty"`
+	Actor       AuditLogActor    `json:"actor"`
+	Details     json.RawMessage  `json:"-"` // Event-specific payload, stored under the key named after Type
+}
+
+// UnmarshalJSON decodes an audit log and captures its event-specific payload in Details.
+func (l *AuditLog) UnmarshalJSON(data []byte) error {
+	type auditLog AuditLog
+	var decoded auditLog
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	decoded.Details = fields[decoded.Type]
+	*l = AuditLog(decoded)
+	return nil
+}
+
+// AuditLogProject represents the project an audit log event belongs to.
+type AuditLogProject struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// AuditLogActor represents the session or API key that performed an audit log event.
+type AuditLogActor struct {
+	Type    string               `json:"type"` // "session" or "api_key"
+	Session *AuditLogSession     `json:"session,omitempty"`
+	APIKey  *AuditLogActorAPIKey `json:"api_key,omitempty"`
+}
+
+// AuditLogSession represents a user session that performed an audit log event.
+type AuditLogSession struct {
+	User      AuditLogUser `json:"user"`
+	IPAddress string       `json:"ip_address"`
+	UserAgent string       `json:"user_agent,omitempty"`
+}
+
+// AuditLogActorAPIKey represents an API key that performed an audit log event.
+type AuditLogActorAPIKey struct {
+	ID             string                  `json:"id"`
+	Type           string                  `json:"type"` // "user" or "service_account"
+	User           *AuditLogUser           `json:"user,omitempty"`
+	ServiceAccount *AuditLogServiceAccount `json:"service_account,omitempty"`
+}
+
+// AuditLogUser represents a user in an audit log event.
+type AuditLogUser struct {
+	ID    string `json:"id"`
+	Email string `json:"email"`
+}
+
+// AuditLogServiceAccount represents a service account in an audit log event.
+type AuditLogServiceAccount struct {
+	ID string `json:"id"`
+}
+
+// ListAuditLogResponse represents the response from the list audit logs API.
+type ListAuditLogResponse struct {
+	Object  string     `json:"object"`
+	Data    []AuditLog `json:"data"`
+	FirstID string     `json:"first_id"`
+	LastID  string     `json:"last_id"`
+	HasMore bool       `json:"has_more"`
+}
+
+// AuditLogs returns an iterator over audit log events matching the filter, newest first.
+// Pages are fetched lazily, so stopping the iteration early avoids requesting the rest of the history.
+func (c *Client) AuditLogs(ctx context.Context, filter AuditLogFilter) iter.Seq2[AuditLog, error] {
+	const pageSize = 100
+	return paginate(func(after string) ([]AuditLog, string, bool, error) {
+		resp, err := c.listAuditLogs(ctx, filter, after, pageSize)
+		if err != nil {
+			return nil, "", false, fmt.Errorf("get audit log list: %w", err)
+		}
+		return resp.Data, resp.LastID, resp.HasMore, nil
+	})
+}
+
+// listAuditLogs retrieves a page of audit logs with filtering and pagination options.
+func (c *Client) listAuditLogs(ctx context.Context, filter AuditLogFilter, after string, limit int) (*ListAuditLogResponse, error) {
+	query := filter.values()
+	if after != "" {
+		query.Set("after", after)
+	}
+	if limit > 0 {
+		query.Set("limit", strconv.Itoa(limit))
+	}
+
+	respBody, err := c.doRequest(ctx, "GET", "/audit_logs", query, nil)
+	if err != nil {
+		return nil, fmt.Errorf("execute request: %w", err)
+	}
+	var result ListAuditLogResponse
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		return nil, fmt.Errorf("unmarshal json: %w", err)
+	}
+	return &result, nil
+}
@@ -0,0 +1,205 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAuditLogFilterValues(t *testing.T) {
+	f := AuditLogFilter{
+		EventTypes:      []string{AuditEventAPIKeyCreated, AuditEventServiceAccountDeleted},
+		ProjectIDs:      []string{"proj_123"},
+		ActorEmails:     []string{"admin@example.com"},
+		EffectiveAfter:  time.Unix(1730419200, 0),
+		EffectiveBefore: time.Unix(1730505600, 0),
+	}
+
+	values := f.values()
+
+	expected := map[string][]string{
+		"event_types[]":     {"api_key.created", "service_account.deleted"},
+		"project_ids[]":     {"proj_123"},
+		"actor_emails[]":    {"admin@example.com"},
+		"effective_at[gte]": {"1730419200"},
+		"effective_at[lt]":  {"1730505600"},
+	}
+	if !reflect.DeepEqual(map[string][]string(values), expected) {
+		t.Errorf("Expected query values to be %v, got %v", expected, values)
+	}
+}
+
+func TestAuditLogs(t *testing.T) {
+	// Test data
+	firstPageBody := `{
+		"object": "list",
+		"data": [{
+			"id": "audit_log-1",
+			"type": "service_account.created",
+			"effective_at": 1720804090,
+			"project": {"id": "proj_123", "name": "personal"},
+			"actor": {
+				"type": "api_key",
+				"api_key": {
+					"id": "key_admin",
+					"type": "user",
+					"user": {"id": "user_123", "email": "admin@example.com"}
+				}
+			},
+			"service_account.created": {
+				"id": "svc_acct_123",
+				"data": {"role": "member"}
+			}
+		}],
+		"first_id": "audit_log-1",
+		"last_id": "audit_log-1",
+		"has_more": true
+	}`
+	secondPageBody := `{
+		"object": "list",
+		"data": [{
+			"id": "audit_log-2",
+			"type": "login.succeeded",
+			"effective_at": 1720804000,
+			"actor": {
+				"type": "session",
+				"session": {
+					"user": {"id": "user_123", "email": "admin@example.com"},
+					"ip_address": "127.0.0.1"
+				}
+			}
+		}],
+		"first_id": "audit_log-2",
+		"last_id": "audit_log-2",
+		"has_more": false
+	}`
+
+	// Create mock HTTP client
+	callCount := 0
+	mockClient := &MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			callCount++
+			if req.URL.Path != "/v1/organization/audit_logs" {
+				t.Errorf("Expected path to be /v1/organization/audit_logs, got %s", req.URL.Path)
+			}
+			body := firstPageBody
+			if callCount == 2 {
+				if req.URL.Query().Get("after") != "audit_log-1" {
+					t.Errorf("Expected 'after' query parameter to be 'audit_log-1' on second call, got '%s'", req.URL.Query().Get("after"))
+				}
+				body = secondPageBody
+			}
+			return &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(strings.NewReader(body)),
+			}, nil
+		},
+	}
+
+	// Create client
+	client := &Client{
+		APIKey:     "test-api-key",
+		HTTPClient: mockClient,
+		BaseURL:    "https://api.openai.com/v1/organization",
+	}
+
+	// Test AuditLogs
+	var logs []AuditLog
+	for log, err := range client.AuditLogs(context.Background(), AuditLogFilter{}) {
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		logs = append(logs, log)
+	}
+
+	// Verify result
+	if len(logs) != 2 {
+		t.Fatalf("Expected 2 audit logs, got %d", len(logs))
+	}
+	if logs[0].Project == nil || logs[0].Project.ID != "proj_123" {
+		t.Errorf("Expected project proj_123, got %+v", logs[0].Project)
+	}
+	if logs[0].Actor.APIKey == nil || logs[0].Actor.APIKey.User.Email != "admin@example.com" {
+		t.Errorf("Expected api key actor admin@example.com, got %+v", logs[0].Actor)
+	}
+	if !strings.Contains(string(logs[0].Details), "svc_acct_123") {
+		t.Errorf("Expected details to contain svc_acct_123, got %s", string(logs[0].Details))
+	}
+	if logs[1].Actor.Session == nil || logs[1].Actor.Session.IPAddress != "127.0.0.1" {
+		t.Errorf("Expected session actor from 127.0.0.1, got %+v", logs[1].Actor)
+	}
+	if logs[1].Details != nil {
+		t.Errorf("Expected no details, got %s", string(logs[1].Details))
+	}
+	if callCount != 2 {
+		t.Errorf("Expected 2 API calls, got %d", callCount)
+	}
+}
+
+func TestAuditLogs_EarlyExit(t *testing.T) {
+	// Create mock HTTP client
+	callCount := 0
+	mockClient := &MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			callCount++
+			return &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(strings.NewReader(`{"object":"list","data":[{"id":"audit_log-1"},{"id":"audit_log-2"}],"last_id":"audit_log-2","has_more":true}`)),
+			}, nil
+		},
+	}
+
+	// Create client
+	client := &Client{
+		APIKey:     "test-api-key",
+		HTTPClient: mockClient,
+		BaseURL:    "https://api.openai.com/v1/organization",
+	}
+
+	// Test AuditLogs stops fetching once the caller stops iterating
+	for log, err := range client.AuditLogs(context.Background(), AuditLogFilter{}) {
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if log.ID == "audit_log-1" {
+			break
+		}
+	}
+
+	// Verify result
+	if callCount != 1 {
+		t.Errorf("Expected 1 API call, got %d", callCount)
+	}
+}
+
+func TestAuditLogs_Error(t *testing.T) {
+	// Create mock HTTP client that returns an error
+	mockClient := &MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			return nil, errors.New("http client error")
+		},
+	}
+
+	// Create client
+	client := &Client{
+		APIKey:     "test-api-key",
+		HTTPClient: mockClient,
+		BaseURL:    "https://api.openai.com/v1/organization",
+	}
+
+	// Test AuditLogs
+	var gotErr error
+	for _, err := range client.AuditLogs(context.Background(), AuditLogFilter{}) {
+		gotErr = err
+	}
+
+	// Verify error
+	if gotErr == nil || !strings.Contains(gotErr.Error(), "http client error") {
+		t.Errorf("Expected error to contain 'http client error', got '%v'", gotErr)
+	}
+}
@@ -0,0 +1,381 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// BreakerState is the state of a Breaker.
+type BreakerState int
+
+const (
+	BreakerClosed   BreakerState = iota // Requests pass through
+	BreakerOpen                         // Requests fail fast with ErrCircuitOpen
+	BreakerHalfOpen                     // A single probe request is allowed through
+)
+
+func (s BreakerState) String() string {
+	switch s {
+	case BreakerClosed:
+		return "closed"
+	case BreakerOpen:
+		return "open"
+	case BreakerHalfOpen:
+		return "half-open"
+	}
+	return fmt.Sprintf("BreakerState(%d)", int(s))
+}
+
+// ErrCircuitOpen is matched by errors returned while the breaker is open.
+var ErrCircuitOpen = errors.New("circuit open")
+
+// CircuitOpenError is returned instead of calling the API while the breaker is open.
+type CircuitOpenError struct {
+	RetryAfter time.Duration // Time until the breaker allows a probe request
+}
+
+func (e *CircuitOpenError) Error() string {
+	return fmt.Sprintf("openai api unavailable: circuit open, retry after %s", e.RetryAfter.Round(time.Second))
+}
+
+// Is reports whether the target is ErrCircuitOpen.
+func (e *CircuitOpenError) Is(target error) bool {
+	return target == ErrCircuitOpen
+}
+
+// BreakerConfig configures a Breaker.
+type BreakerConfig struct {
+	FailureThreshold int                                      // Consecutive failures that open the breaker
+	OpenTimeout      time.Duration                            // How long the breaker stays open before probing
+	OnStateChange    func(from BreakerState, to BreakerState) // Called after every state change, if set
+}
+
+// Breaker is a circuit breaker around an APIClient. After FailureThreshold consecutive
+// upstream failures it fails fast for OpenTimeout, then lets one probe request through
+// and closes again if the probe succeeds.
+type Breaker struct {
+	next     APIClient
+	config   BreakerConfig
+	mu       sync.Mutex
+	state    BreakerState
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+// NewBreaker wraps next with a circuit breaker.
+func NewBreaker(next APIClient, config BreakerConfig) *Breaker {
+	if config.FailureThreshold < 1 {
+		config.FailureThreshold = 1
+	}
+	return &Breaker{
+		next:   next,
+		config: config,
+	}
+}
+
+// State returns the current state of the breaker.
+func (b *Breaker) State() BreakerState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.state == BreakerOpen && time.Since(b.openedAt) >= b.config.OpenTimeout {
+		return BreakerHalfOpen
+	}
+	return b.state
+}
+
+// allow reports whether a request may be sent, moving an expired open breaker to half-open.
+// probe is true for the single request whose outcome decides whether a half-open breaker closes.
+func (b *Breaker) allow() (probe bool, err error) {
+	b.mu.Lock()
+	var from, to BreakerState
+	changed := false
+	defer func() {
+		b.mu.Unlock()
+		if changed {
+			b.notify(from, to)
+		}
+	}()
+
+	switch b.state {
+	case BreakerOpen:
+		remaining := b.config.OpenTimeout - time.Since(b.openedAt)
+		if remaining > 0 {
+			return false, &CircuitOpenError{RetryAfter: remaining}
+		}
+		from, to, changed = b.state, BreakerHalfOpen, true
+		b.state = BreakerHalfOpen
+		b.probing = true
+		return true, nil
+	case BreakerHalfOpen:
+		if b.probing {
+			return false, &CircuitOpenError{}
+		}
+		b.probing = true
+		return true, nil
+	}
+	return false, nil
+}
+
+// record updates the breaker with the outcome of a request. Outcomes of requests that started
+// before the breaker opened are ignored: only the probe decides whether an open breaker closes.
+func (b *Breaker) record(probe bool, err error) {
+	b.mu.Lock()
+	from := b.state
+	switch {
+	case b.state == BreakerOpen || (b.state == BreakerHalfOpen && !probe):
+		// A stale outcome from before the breaker opened.
+	case isNeutral(err):
+		// The caller gave up; this says nothing about the API.
+		b.probing = false
+	case isUpstreamFailure(err):
+		b.failures++
+		if b.state == BreakerHalfOpen || b.failures >= b.config.FailureThreshold {
+			b.state = BreakerOpen
+			b.openedAt = time.Now()
+		}
+		b.probing = false
+	default:
+		b.failures = 0
+		b.state = BreakerClosed
+		b.probing = false
+	}
+	to := b.state
+	b.mu.Unlock()
+
+	b.notify(from, to)
+}
+
+func (b *Breaker) notify(from BreakerState, to BreakerState) {
+	if from != to && b.config.OnStateChange != nil {
+		b.config.OnStateChange(from, to)
+	}
+}
+
+// isNeutral reports whether the error was caused by the caller rather than the API.
+func isNeutral(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, ErrLimiterDeadline)
+}
+
+// isUpstreamFailure reports whether the error indicates that the API is unhealthy.
+// Client errors such as 404 or 409 show the API is responding and do not count.
+func isUpstreamFailure(err error) bool {
+	if err == nil || isNeutral(err) || errors.Is(err, ErrQuotaExceeded) {
+		return false
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode >= http.StatusInternalServerError || apiErr.StatusCode == http.StatusTooManyRequests
+	}
+	return true
+}
+
+// call runs fn through the breaker.
+func call[T any](b *Breaker, fn func() (T, error)) (T, error) {
+	probe, err := b.allow()
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	result, err := fn()
+	b.record(probe, err)
+	return result, err
+}
+
+// ServiceAccounts returns an iterator over all service accounts for a project. The iteration
+// counts as a single request: its first error, or its completion, is recorded.
+func (b *Breaker) ServiceAccounts(ctx context.Context, projectID string) iter.Seq2[ServiceAccount, error] {
+	return func(yield func(ServiceAccount, error) bool) {
+		probe, err := b.allow()
+		if err != nil {
+			yield(ServiceAccount{}, err)
+			return
+		}
+		var failed error
+		defer func() { b.record(probe, failed) }()
+		for serviceAccount, err := range b.next.ServiceAccounts(ctx, projectID) {
+			if err != nil {
+				failed = err
+			}
+			if !yield(serviceAccount, err) {
+				return
+			}
+		}
+	}
+}
+
+// projectCacher is implemented by clients that can answer GetProject without calling the API.
+type projectCacher interface {
+	cachedProject(projectName string) (*Project, bool)
+}
+
+// GetProject calls the wrapped client's GetProject through the breaker. Projects served from the
+// wrapped client's cache never reach the API, so they are returned without being recorded.
+func (b *Breaker) GetProject(ctx context.Context, projectName string) (*Project, bool, error) {
+	if cache, ok := b.next.(projectCacher); ok {
+		if project, ok := cache.cachedProject(projectName); ok {
+			return project, true, nil
+		}
+	}
+	probe, err := b.allow()
+	if err != nil {
+		return nil, false, err
+	}
+	result, found, err := b.next.GetProject(ctx, projectName)
+	b.record(probe, err)
+	return result, found, err
+}
+
+// GetProjectByID calls the wrapped client's GetProjectByID through the breaker.
+func (b *Breaker) GetProjectByID(ctx context.Context, projectID string) (*Project, error) {
+	return call(b, func() (*Project, error) { return b.next.GetProjectByID(ctx, projectID) })
+}
+
+// CreateProject calls the wrapped client's CreateProject through the breaker.
+func (b *Breaker) CreateProject(ctx context.Context, name string) (*Project, error) {
+	return call(b, func() (*Project, error) { return b.next.CreateProject(ctx, name) })
+}
+
+// ModifyProject calls the wrapped client's ModifyProject through the breaker.
+func (b *Breaker) ModifyProject(ctx context.Context, projectID string, name string) (*Project, error) {
+	return call(b, func() (*Project, error) { return b.next.ModifyProject(ctx, projectID, name) })
+}
+
+// ArchiveProject calls the wrapped client's ArchiveProject through the breaker.
+func (b *Breaker) ArchiveProject(ctx context.Context, projectID string) (*Project, error) {
+	return call(b, func() (*Project, error) { return b.next.ArchiveProject(ctx, projectID) })
+}
+
+// CreateServiceAccount calls the wrapped client's CreateServiceAccount through the breaker.
+func (b *Breaker) CreateServiceAccount(ctx context.Context, projectID string, name string) (*ServiceAccount, error) {
+	return call(b, func() (*ServiceAccount, error) { return b.next.CreateServiceAccount(ctx, projectID, name) })
+}
+
+// GetServiceAccount calls the wrapped client's GetServiceAccount through the breaker.
+func (b *Breaker) GetServiceAccount(ctx context.Context, projectID string, serviceAccountID string, keyID string) (*ServiceAccount, error) {
+	return call(b, func() (*ServiceAccount, error) {
+		return b.next.GetServiceAccount(ctx, projectID, serviceAccountID, keyID)
+	})
+}
+
+// ListServiceAccounts calls the wrapped client's ListServiceAccounts through the breaker.
+func (b *Breaker) ListServiceAccounts(ctx context.Context, projectID string) (*[]ServiceAccount, error) {
+	return call(b, func() (*[]ServiceAccount, error) { return b.next.ListServiceAccounts(ctx, projectID) })
+}
+
+// DeleteServiceAccount calls the wrapped client's DeleteServiceAccount through the breaker.
+func (b *Breaker) DeleteServiceAccount(ctx context.Context, projectID string, serviceAccountID string) (*DeletedServiceAccountResponse, error) {
+	return call(b, func() (*DeletedServiceAccountResponse, error) {
+		return b.next.DeleteServiceAccount(ctx, projectID, serviceAccountID)
+	})
+}
+
+// ListProjectAPIKeys calls the wrapped client's ListProjectAPIKeys through the breaker.
+func (b *Breaker) ListProjectAPIKeys(ctx context.Context, projectID string) (*[]ProjectAPIKey, error) {
+	return call(b, func() (*[]ProjectAPIKey, error) { return b.next.ListProjectAPIKeys(ctx, projectID) })
+}
+
+// GetProjectAPIKey calls the wrapped client's GetProjectAPIKey through the breaker.
+func (b *Breaker) GetProjectAPIKey(ctx context.Context, projectID string, keyID string) (*ProjectAPIKey, error) {
+	return call(b, func() (*ProjectAPIKey, error) { return b.next.GetProjectAPIKey(ctx, projectID, keyID) })
+}
+
+// DeleteProjectAPIKey calls the wrapped client's DeleteProjectAPIKey through the breaker.
+func (b *Breaker) DeleteProjectAPIKey(ctx context.Context, projectID string, keyID string) (*DeletedProjectAPIKeyResponse, error) {
+	return call(b, func() (*DeletedProjectAPIKeyResponse, error) {
+		return b.next.DeleteProjectAPIKey(ctx, projectID, keyID)
+	})
+}
+
+// ListProjectRateLimits calls the wrapped client's ListProjectRateLimits through the breaker.
+func (b *Breaker) ListProjectRateLimits(ctx context.Context, projectID string) (*[]ProjectRateLimit, error) {
+	return call(b, func() (*[]ProjectRateLimit, error) { return b.next.ListProjectRateLimits(ctx, projectID) })
+}
+
+// ModifyProjectRateLimit calls the wrapped client's ModifyProjectRateLimit through the breaker.
+func (b *Breaker) ModifyProjectRateLimit(ctx context.Context, projectID string, rateLimitID string, limits ModifyProjectRateLimitRequest) (*ProjectRateLimit, error) {
+	return call(b, func() (*ProjectRateLimit, error) {
+		return b.next.ModifyProjectRateLimit(ctx, projectID, rateLimitID, limits)
+	})
+}
+
+// ListUsers calls the wrapped client's ListUsers through the breaker.
+func (b *Breaker) ListUsers(ctx context.Context) (*[]User, error) {
+	return call(b, func() (*[]User, error) { return b.next.ListUsers(ctx) })
+}
+
+// GetUser calls the wrapped client's GetUser through the breaker.
+func (b *Breaker) GetUser(ctx context.Context, userID string) (*User, error) {
+	return call(b, func() (*User, error) { return b.next.GetUser(ctx, userID) })
+}
+
+// GetUserByEmail calls the wrapped client's GetUserByEmail through the breaker.
+func (b *Breaker) GetUserByEmail(ctx context.Context, email string) (*User, bool, error) {
+	probe, err := b.allow()
+	if err != nil {
+		return nil, false, err
+	}
+	result, found, err := b.next.GetUserByEmail(ctx, email)
+	b.record(probe, err)
+	return result, found, err
+}
+
+// ModifyUserRole calls the wrapped client's ModifyUserRole through the breaker.
+func (b *Breaker) ModifyUserRole(ctx context.Context, userID string, role string) (*User, error) {
+	return call(b, func() (*User, error) { return b.next.ModifyUserRole(ctx, userID, role) })
+}
+
+// DeleteUser calls the wrapped client's DeleteUser through the breaker.
+func (b *Breaker) DeleteUser(ctx context.Context, userID string) (*DeletedUserResponse, error) {
+	return call(b, func() (*DeletedUserResponse, error) { return b.next.DeleteUser(ctx, userID) })
+}
+
+// ListInvites calls the wrapped client's ListInvites through the breaker.
+func (b *Breaker) ListInvites(ctx context.Context) (*[]Invite, error) {
+	return call(b, func() (*[]Invite, error) { return b.next.ListInvites(ctx) })
+}
+
+// CreateInvite calls the wrapped client's CreateInvite through the breaker.
+func (b *Breaker) CreateInvite(ctx context.Context, invite CreateInviteRequest) (*Invite, error) {
+	return call(b, func() (*Invite, error) { return b.next.CreateInvite(ctx, invite) })
+}
+
+// GetInvite calls the wrapped client's GetInvite through the breaker.
+func (b *Breaker) GetInvite(ctx context.Context, inviteID string) (*Invite, error) {
+	return call(b, func() (*Invite, error) { return b.next.GetInvite(ctx, inviteID) })
+}
+
+// DeleteInvite calls the wrapped client's DeleteInvite through the breaker.
+func (b *Breaker) DeleteInvite(ctx context.Context, inviteID string) (*DeletedInviteResponse, error) {
+	return call(b, func() (*DeletedInviteResponse, error) { return b.next.DeleteInvite(ctx, inviteID) })
+}
+
+// ListProjectUsers calls the wrapped client's ListProjectUsers through the breaker.
+func (b *Breaker) ListProjectUsers(ctx context.Context, projectID string) (*[]ProjectUser, error) {
+	return call(b, func() (*[]ProjectUser, error) { return b.next.ListProjectUsers(ctx, projectID) })
+}
+
+// AddProjectUser calls the wrapped client's AddProjectUser through the breaker.
+func (b *Breaker) AddProjectUser(ctx context.Context, projectID string, userID string, role string) (*ProjectUser, error) {
+	return call(b, func() (*ProjectUser, error) { return b.next.AddProjectUser(ctx, projectID, userID, role) })
+}
+
+// GetProjectUser calls the wrapped client's GetProjectUser through the breaker.
+func (b *Breaker) GetProjectUser(ctx context.Context, projectID string, userID string) (*ProjectUser, error) {
+	return call(b, func() (*ProjectUser, error) { return b.next.GetProjectUser(ctx, projectID, userID) })
+}
+
+// ModifyProjectUserRole calls the wrapped client's ModifyProjectUserRole through the breaker.
+func (b *Breaker) ModifyProjectUserRole(ctx context.Context, projectID string, userID string, role string) (*ProjectUser, error) {
+	return call(b, func() (*ProjectUser, error) { return b.next.ModifyProjectUserRole(ctx, projectID, userID, role) })
+}
+
+// DeleteProjectUser calls the wrapped client's DeleteProjectUser through the breaker.
+func (b *Breaker) DeleteProjectUser(ctx context.Context, projectID string, userID string) (*DeletedProjectUserResponse, error) {
+	return call(b, func() (*DeletedProjectUserResponse, error) { return b.next.DeleteProjectUser(ctx, projectID, userID) })
+}
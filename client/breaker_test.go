@@ -0,0 +1,285 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newBreakerTestClient(status *int, callCount *int) *Client {
+	return &Client{
+		APIKey: "test-api-key",
+		HTTPClient: &MockHTTPClient{
+			DoFunc: func(req *http.Request) (*http.Response, error) {
+				*callCount++
+				return &http.Response{
+					StatusCode: *status,
+					Body:       io.NopCloser(strings.NewReader(`{"id":"proj_1","name":"test-project"}`)),
+				}, nil
+			},
+		},
+		BaseURL: "https://api.openai.com/v1/organization",
+	}
+}
+
+func TestBreaker_OpensAndRecovers(t *testing.T) {
+	// Test data
+	status := http.StatusBadGateway
+	callCount := 0
+	var transitions []string
+
+	// Create breaker
+	breaker := NewBreaker(newBreakerTestClient(&status, &callCount), BreakerConfig{
+		FailureThreshold: 2,
+		OpenTimeout:      20 * time.Millisecond,
+		OnStateChange: func(from BreakerState, to BreakerState) {
+			transitions = append(transitions, from.String()+"->"+to.String())
+		},
+	})
+	ctx := context.Background()
+
+	// Consecutive failures open the breaker
+	for i := 0; i < 2; i++ {
+		if _, err := breaker.GetProjectByID(ctx, "proj_1"); err == nil {
+			t.Fatal("Expected error, got nil")
+		}
+	}
+	if breaker.State() != BreakerOpen {
+		t.Fatalf("Expected breaker to be open, got %s", breaker.State())
+	}
+
+	// Open breaker fails fast without calling the API
+	_, err := breaker.GetProjectByID(ctx, "proj_1")
+	var openErr *CircuitOpenError
+	if !errors.Is(err, ErrCircuitOpen) || !errors.As(err, &openErr) || openErr.RetryAfter <= 0 {
+		t.Errorf("Expected *CircuitOpenError with RetryAfter, got %v", err)
+	}
+	if callCount != 2 {
+		t.Errorf("Expected 2 API calls, got %d", callCount)
+	}
+
+	// A failed probe reopens the breaker
+	time.Sleep(25 * time.Millisecond)
+	if _, err := breaker.GetProjectByID(ctx, "proj_1"); errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("Expected probe request to reach the API, got %v", err)
+	}
+	if breaker.State() != BreakerOpen {
+		t.Fatalf("Expected breaker to be open after a failed probe, got %s", breaker.State())
+	}
+
+	// A successful probe closes the breaker
+	status = http.StatusOK
+	time.Sleep(25 * time.Millisecond)
+	if _, err := breaker.GetProjectByID(ctx, "proj_1"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if breaker.State() != BreakerClosed {
+		t.Errorf("Expected breaker to be closed, got %s", breaker.State())
+	}
+
+	// Verify transitions
+	expected := []string{"closed->open", "open->half-open", "half-open->open", "open->half-open", "half-open->closed"}
+	if strings.Join(transitions, ",") != strings.Join(expected, ",") {
+		t.Errorf("Expected transitions %v, got %v", expected, transitions)
+	}
+}
+
+func TestBreaker_ClientErrorsDoNotOpen(t *testing.T) {
+	// Test data
+	status := http.StatusNotFound
+	callCount := 0
+
+	// Create breaker
+	breaker := NewBreaker(newBreakerTestClient(&status, &callCount), BreakerConfig{FailureThreshold: 1, OpenTimeout: time.Minute})
+
+	// Test GetProjectByID
+	for i := 0; i < 3; i++ {
+		if _, err := breaker.GetProjectByID(context.Background(), "proj_1"); !errors.Is(err, ErrNotFound) {
+			t.Fatalf("Expected error to be %v, got %v", ErrNotFound, err)
+		}
+	}
+
+	// Verify result
+	if breaker.State() != BreakerClosed {
+		t.Errorf("Expected breaker to stay closed, got %s", breaker.State())
+	}
+	if callCount != 3 {
+		t.Errorf("Expected 3 API calls, got %d", callCount)
+	}
+}
+
+func TestBreaker_StaleSuccessDoesNotClose(t *testing.T) {
+	// Test data
+	started := make(chan struct{})
+	release := make(chan struct{})
+	var transitions []string
+
+	// Create mock HTTP client: the "slow" project succeeds only after it is released
+	client := &Client{
+		APIKey: "test-api-key",
+		HTTPClient: &MockHTTPClient{
+			DoFunc: func(req *http.Request) (*http.Response, error) {
+				if strings.HasSuffix(req.URL.Path, "/slow") {
+					close(started)
+					<-release
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Body:       io.NopCloser(strings.NewReader(`{"id":"slow","name":"test-project"}`)),
+					}, nil
+				}
+				return &http.Response{StatusCode: http.StatusBadGateway, Body: io.NopCloser(strings.NewReader(""))}, nil
+			},
+		},
+		BaseURL: "https://api.openai.com/v1/organization",
+	}
+
+	// Create breaker
+	breaker := NewBreaker(client, BreakerConfig{
+		FailureThreshold: 1,
+		OpenTimeout:      time.Minute,
+		OnStateChange: func(from BreakerState, to BreakerState) {
+			transitions = append(transitions, from.String()+"->"+to.String())
+		},
+	})
+	ctx := context.Background()
+
+	// Start a request while the breaker is closed
+	done := make(chan error)
+	go func() {
+		_, err := breaker.GetProjectByID(ctx, "slow")
+		done <- err
+	}()
+	<-started
+
+	// Open the breaker while the request is in flight
+	if _, err := breaker.GetProjectByID(ctx, "proj_1"); err == nil {
+		t.Fatal("Expected error, got nil")
+	}
+	if breaker.State() != BreakerOpen {
+		t.Fatalf("Expected breaker to be open, got %s", breaker.State())
+	}
+
+	// The in-flight request succeeds after the breaker opened
+	close(release)
+	if err := <-done; err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// Verify result
+	if breaker.State() != BreakerOpen {
+		t.Errorf("Expected breaker to stay open, got %s", breaker.State())
+	}
+	if len(transitions) != 1 || transitions[0] != "closed->open" {
+		t.Errorf("Expected transitions [closed->open], got %v", transitions)
+	}
+}
+
+func TestBreaker_ServiceAccounts(t *testing.T) {
+	// Test data
+	status := http.StatusServiceUnavailable
+	callCount := 0
+
+	// Create breaker
+	breaker := NewBreaker(newBreakerTestClient(&status, &callCount), BreakerConfig{FailureThreshold: 1, OpenTimeout: time.Minute})
+
+	// A failed iteration opens the breaker
+	for _, err := range breaker.ServiceAccounts(context.Background(), "proj_1") {
+		if err == nil {
+			t.Fatal("Expected error, got nil")
+		}
+	}
+	if breaker.State() != BreakerOpen {
+		t.Fatalf("Expected breaker to be open, got %s", breaker.State())
+	}
+
+	// Further iterations fail fast
+	for _, err := range breaker.ServiceAccounts(context.Background(), "proj_1") {
+		if !errors.Is(err, ErrCircuitOpen) {
+			t.Errorf("Expected error to be %v, got %v", ErrCircuitOpen, err)
+		}
+	}
+	if callCount != 1 {
+		t.Errorf("Expected 1 API call, got %d", callCount)
+	}
+}
+
+func TestBreaker_CachedProjectNotRecorded(t *testing.T) {
+	// Create mock HTTP client: projects list fine, service account creation fails upstream
+	callCount := 0
+	mockClient := &MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			callCount++
+			if strings.HasSuffix(req.URL.Path, "/service_accounts") {
+				return &http.Response{StatusCode: http.StatusBadGateway, Body: io.NopCloser(strings.NewReader(""))}, nil
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body: io.NopCloser(strings.NewReader(`{"object":"list","data":[` +
+					`{"id":"proj_1","name":"test-project","status":"active"}],` +
+					`"first_id":"proj_1","last_id":"proj_1","has_more":false}`)),
+			}, nil
+		},
+	}
+
+	// Create breaker around a client with a project cache
+	breaker := NewBreaker(&Client{
+		APIKey:       "test-api-key",
+		HTTPClient:   mockClient,
+		BaseURL:      "https://api.openai.com/v1/organization",
+		ProjectCache: NewProjectCache(time.Minute),
+	}, BreakerConfig{FailureThreshold: 3, OpenTimeout: time.Minute})
+	ctx := context.Background()
+
+	// Each round looks up the project, then fails to create a service account
+	for i := 0; i < 3; i++ {
+		project, found, err := breaker.GetProject(ctx, "test-project")
+		if err != nil || !found {
+			t.Fatalf("Expected project to be found, got found=%v err=%v", found, err)
+		}
+		if _, err := breaker.CreateServiceAccount(ctx, project.ID, "sa"); err == nil {
+			t.Fatal("Expected error, got nil")
+		}
+	}
+
+	// Verify result: cache hits do not reset the failure count
+	if breaker.State() != BreakerOpen {
+		t.Errorf("Expected breaker to be open, got %s", breaker.State())
+	}
+	if callCount != 4 {
+		t.Errorf("Expected 4 API calls, got %d", callCount)
+	}
+
+	// Cached projects are still served while the breaker is open
+	if _, found, err := breaker.GetProject(ctx, "test-project"); err != nil || !found {
+		t.Errorf("Expected cached project while open, got found=%v err=%v", found, err)
+	}
+}
+
+func TestIsUpstreamFailure(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{name: "Nil", err: nil, expected: false},
+		{name: "Server error", err: &APIError{StatusCode: 502}, expected: true},
+		{name: "Rate limited", err: &APIError{StatusCode: 429}, expected: true},
+		{name: "Quota exceeded", err: &APIError{StatusCode: 429, Code: "insufficient_quota"}, expected: false},
+		{name: "Not found", err: &APIError{StatusCode: 404}, expected: false},
+		{name: "Transport error", err: errors.New("connection refused"), expected: true},
+		{name: "Deadline exceeded", err: context.DeadlineExceeded, expected: true},
+		{name: "Canceled", err: context.Canceled, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isUpstreamFailure(tt.err); got != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
@@ -4,11 +4,14 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"iter"
 	"log/slog"
 	"net/http"
 	"net/url"
+	"time"
 )
 
 // HTTPClient defines the interface for making HTTP requests.
@@ -19,27 +22,49 @@ type HTTPClient interface {
 // APIClient defines the interface for OpenAI API operations.
 type APIClient interface {
 	GetProject(ctx context.Context, projectName string) (*Project, bool, error)
+	GetProjectByID(ctx context.Context, projectID string) (*Project, error)
 	CreateProject(ctx context.Context, name string) (*Project, error)
+	ModifyProject(ctx context.Context, projectID string, name string) (*Project, error)
+	ArchiveProject(ctx context.Context, projectID string) (*Project, error)
 	CreateServiceAccount(ctx context.Context, projectID string, name string) (*ServiceAccount, error)
+	// GetServiceAccount takes the key ID rather than resolving it: the API offers no lookup of a
+	// service account's key other than listing every key in the project, so callers pass the ID
+	// recorded at issuance, as Management.GetAPIKey does, and an empty keyID returns the account alone.
+	GetServiceAccount(ctx context.Context, projectID string, serviceAccountID string, keyID string) (*ServiceAccount, error)
 	ListServiceAccounts(ctx context.Context, projectID string) (*[]ServiceAccount, error)
+	ServiceAccounts(ctx context.Context, projectID string) iter.Seq2[ServiceAccount, error]
 	DeleteServiceAccount(ctx context.Context, projectID string, serviceAccountID string) (*DeletedServiceAccountResponse, error)
+	ListProjectAPIKeys(ctx context.Context, projectID string) (*[]ProjectAPIKey, error)
+	GetProjectAPIKey(ctx context.Context, projectID string, keyID string) (*ProjectAPIKey, error)
+	DeleteProjectAPIKey(ctx context.Context, projectID string, keyID string) (*DeletedProjectAPIKeyResponse, error)
+	ListProjectRateLimits(ctx context.Context, projectID string) (*[]ProjectRateLimit, error)
+	ModifyProjectRateLimit(ctx context.Context, projectID string, rateLimitID string, limits ModifyProjectRateLimitRequest) (*ProjectRateLimit, error)
+	ListUsers(ctx context.Context) (*[]User, error)
+	GetUser(ctx context.Context, userID string) (*User, error)
+	GetUserByEmail(ctx context.Context, email string) (*User, bool, error)
+	ModifyUserRole(ctx context.Context, userID string, role string) (*User, error)
+	DeleteUser(ctx context.Context, userID string) (*DeletedUserResponse, error)
+	ListInvites(ctx context.Context) (*[]Invite, error)
+	CreateInvite(ctx context.Context, invite CreateInviteRequest) (*Invite, error)
+	GetInvite(ctx context.Context, inviteID string) (*Invite, error)
+	DeleteInvite(ctx context.Context, inviteID string) (*DeletedInviteResponse, error)
+	ListProjectUsers(ctx context.Context, projectID string) (*[]ProjectUser, error)
+	AddProjectUser(ctx context.Context, projectID string, userID string, role string) (*ProjectUser, error)
+	GetProjectUser(ctx context.Context, projectID string, userID string) (*ProjectUser, error)
+	ModifyProjectUserRole(ctx context.Context, projectID string, userID string, role string) (*ProjectUser, error)
+	DeleteProjectUser(ctx context.Context, projectID string, userID string) (*DeletedProjectUserResponse, error)
 }
 
 // Client implements the APIClient interface and handles interactions with the OpenAI API.
 type Client struct {
-	APIKey     string     // API key for authentication
-	HTTPClient HTTPClient // HTTP client for making requests
-	BaseURL    string     // Base URL for API endpoints
-}
-
-// APIError represents an error returned by the OpenAI API.
-type APIError struct {
-	StatusCode int    // HTTP status code
-	Message    string // Error message
-}
-
-func (e *APIError) Error() string {
-	return fmt.Sprintf("receive api response: %s (status code: %d)", e.Message, e.StatusCode)
+	APIKey       string         // API key for authentication
+	HTTPClient   HTTPClient     // HTTP client for making requests
+	BaseURL      string         // Base URL for API endpoints
+	Retry        RetryPolicy    // Retry policy for failed requests; the zero value disables retries
+	Limiter      *Limiter       // Client-side rate limiter shared by all requests; nil disables limiting
+	ProjectCache *ProjectCache  // Cache for project name lookups; nil disables caching
+	Observer     Observer       // Notified after every request; nil disables observation
+	Drift        *DriftDetector // Strict decoding that reports schema drift; nil disables it
 }
 
 // NewClient initializes a new API client with the provided credentials and HTTP client.
@@ -48,11 +73,13 @@ func NewClient(apiKey string, httpClient HTTPClient) *Client {
 		APIKey:     apiKey,
 		HTTPClient: httpClient,
 		BaseURL:    "https://api.openai.com/v1/organization",
+		Retry:      DefaultRetryPolicy(),
 	}
 }
 
 // doRequest performs an HTTP request to the OpenAI API with the specified parameters.
-func (c *Client) doRequest(ctx context.Context, method string, path string, query url.Values, body interface{}) ([]byte, error) {
+// Failed attempts are retried according to the client's retry policy when the method is idempotent.
+func (c *Client) doRequest(ctx context.Context, method string, path string, query url.Values, body interface{}) (respBody []byte, err error) {
 	fullURL := c.BaseURL + path
 	if query != nil {
 		fullURL += "?" + query.Encode()
@@ -67,9 +94,66 @@ func (c *Client) doRequest(ctx context.Context, method string, path string, quer
 		}
 	}
 
+	start := time.Now()
+	var statusCode, retries int
+	if c.Observer != nil {
+		defer func() {
+			c.Observer.ObserveRequest(ctx, RequestInfo{
+				Method:     method,
+				Path:       templatePath(path),
+				StatusCode: statusCode,
+				Latency:    time.Since(start),
+				Retries:    retries,
+				ErrorClass: classifyError(err),
+			})
+		}()
+	}
+
+	retryable := isIdempotent(ctx, method)
+	priority := PriorityFromContext(ctx)
+	for attempt := 1; ; attempt++ {
+		retries = attempt - 1
+		if err := c.Limiter.Wait(ctx, priority); err != nil {
+			return nil, fmt.Errorf("wait for rate limiter: %w", err)
+		}
+		respBody, statusCode, err = c.doAttempt(ctx, method, fullURL, reqBody)
+		if err == nil {
+			return respBody, nil
+		}
+		c.ProjectCache.invalidateOnError(path, err)
+		if !retryable || c.Retry.MaxRetries <= 0 {
+			return nil, err
+		}
+		if !isRetryable(ctx, err) {
+			if attempt == 1 {
+				return nil, err
+			}
+			return nil, &RetryError{Attempts: attempt, Reason: RetryReasonPermanent, Err: err}
+		}
+		if attempt > c.Retry.MaxRetries {
+			return nil, &RetryError{Attempts: attempt, Reason: RetryReasonExhausted, Err: err}
+		}
+
+		delay := c.Retry.backoff(attempt)
+		var apiErr *APIError
+		if errors.As(err, &apiErr) && apiErr.RetryAfter > 0 {
+			delay = apiErr.RetryAfter
+		}
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+			return nil, &RetryError{Attempts: attempt, Reason: RetryReasonDeadline, Err: err}
+		}
+		slog.Debug("retry api request", "method", method, "url", c.BaseURL+path, "attempt", attempt, "delay", delay, "error", err)
+		if !sleep(ctx, delay) {
+			return nil, &RetryError{Attempts: attempt, Reason: RetryReasonCanceled, Err: err}
+		}
+	}
+}
+
+// doAttempt performs a single HTTP request attempt and returns the response body and status code.
+func (c *Client) doAttempt(ctx context.Context, method string, fullURL string, reqBody []byte) ([]byte, int, error) {
 	req, err := http.NewRequestWithContext(ctx, method, fullURL, bytes.NewBuffer(reqBody))
 	if err != nil {
-		return nil, fmt.Errorf("create http request: %w", err)
+		return nil, 0, fmt.Errorf("create http request: %w", err)
 	}
 
 	req.Header.Set("Authorization", "Bearer "+c.APIKey)
@@ -77,7 +161,7 @@ func (c *Client) doRequest(ctx context.Context, method string, path string, quer
 
 	resp, err := c.HTTPClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("execute http request: %w", err)
+		return nil, 0, fmt.Errorf("execute http request: %w", &transportError{err})
 	}
 
 	defer func() {
@@ -88,15 +172,14 @@ func (c *Client) doRequest(ctx context.Context, method string, path string, quer
 
 	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("read response body: %w", err)
+		return nil, resp.StatusCode, fmt.Errorf("read response body: %w", &transportError{err})
 	}
 
 	if resp.StatusCode >= 400 {
-		return nil, &APIError{
-			StatusCode: resp.StatusCode,
-			Message:    string(respBody),
-		}
+		apiErr := newAPIError(resp.StatusCode, respBody)
+		apiErr.RetryAfter, _ = parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
+		return nil, resp.StatusCode, apiErr
 	}
 
-	return respBody, nil
+	return respBody, resp.StatusCode, nil
 }
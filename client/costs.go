@@ -0,0 +1,109 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// CostsQuery holds the time range, filters and grouping for a costs request.
+type CostsQuery struct {
+	StartTime  time.Time // Start of the range, inclusive (required)
+	EndTime    time.Time // End of the range, exclusive; zero means now
+	ProjectIDs []string  // Only return costs for these projects
+	GroupBy    []string  // Fields to group results by: project_id and/or line_item
+	Limit      int       // Number of daily buckets per page
+}
+
+// CostBucket represents the costs within one daily bucket.
+type CostBucket struct {
+	Object    string       `json:"object"`
+	StartTime int64        `json:"start_time"`
+	EndTime   int64        `json:"end_time"`
+	Results   []CostResult `json:"results"`
+}
+
+// CostResult represents the cost of one group within a bucket.
+type CostResult struct {
+	Object    string     `json:"object"`
+	Amount    CostAmount `json:"amount"`
+	LineItem  string     `json:"line_item,omitempty"`
+	ProjectID string     `json:"project_id,omitempty"`
+}
+
+// CostAmount represents a monetary amount.
+type CostAmount struct {
+	Value    float64 `json:"value"`
+	Currency string  `json:"currency"`
+}
+
+// CostsResponse represents a page of buckets from the costs API.
+type CostsResponse struct {
+	Object   string       `json:"object"`
+	Data     []CostBucket `json:"data"`
+	HasMore  bool         `json:"has_more"`
+	NextPage string       `json:"next_page"`
+}
+
+// ListCosts retrieves all daily cost buckets matching the query.
+func (c *Client) ListCosts(ctx context.Context, q CostsQuery) (*[]CostBucket, error) {
+	return collect(c.Costs(ctx, q))
+}
+
+// Costs returns an iterator over the daily cost buckets matching the query.
+func (c *Client) Costs(ctx context.Context, q CostsQuery) iter.Seq2[CostBucket, error] {
+	return paginate(func(page string) ([]CostBucket, string, bool, error) {
+		resp, err := c.listCosts(ctx, q, page)
+		if err != nil {
+			return nil, "", false, fmt.Errorf("get costs: %w", err)
+		}
+		return resp.Data, resp.NextPage, resp.HasMore, nil
+	})
+}
+
+// listCosts retrieves a page of cost buckets starting at the given page cursor.
+func (c *Client) listCosts(ctx context.Context, q CostsQuery, page string) (*CostsResponse, error) {
+	query := url.Values{}
+	query.Set("start_time", strconv.FormatInt(q.StartTime.Unix(), 10))
+	if !q.EndTime.IsZero() {
+		query.Set("end_time", strconv.FormatInt(q.EndTime.Unix(), 10))
+	}
+	query.Set("bucket_width", BucketWidthDay)
+	for _, id := range q.ProjectIDs {
+		query.Add("project_ids[]", id)
+	}
+	for _, field := range q.GroupBy {
+		query.Add("group_by[]", field)
+	}
+	if q.Limit > 0 {
+		query.Set("limit", strconv.Itoa(q.Limit))
+	}
+	if page != "" {
+		query.Set("page", page)
+	}
+
+	respBody, err := c.doRequest(ctx, "GET", "/costs", query, nil)
+	if err != nil {
+		return nil, fmt.Errorf("execute request: %w", err)
+	}
+	var result CostsResponse
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		return nil, fmt.Errorf("unmarshal json: %w", err)
+	}
+	return &result, nil
+}
+
+// TotalCost returns the summed cost per currency across all results in the buckets.
+func TotalCost(buckets []CostBucket) map[string]float64 {
+	totals := map[string]float64{}
+	for _, bucket := range buckets {
+		for _, result := range bucket.Results {
+			totals[result.Amount.Currency] += result.Amount.Value
+		}
+	}
+	return totals
+}
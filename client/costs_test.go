@@ -0,0 +1,134 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestListCosts_Pagination(t *testing.T) {
+	// Test data
+	firstPageBody := `{
+		"object": "page",
+		"data": [{
+			"object": "bucket",
+			"start_time": 1730419200,
+			"end_time": 1730505600,
+			"results": [{
+				"object": "organization.costs.result",
+				"amount": {"value": 0.06, "currency": "usd"},
+				"line_item": "gpt-4o, input",
+				"project_id": "proj_123"
+			}]
+		}],
+		"has_more": true,
+		"next_page": "page_AAAAAGdGxdEiJdKOAAAAAGcqsYA="
+	}`
+	secondPageBody := `{
+		"object": "page",
+		"data": [{
+			"object": "bucket",
+			"start_time": 1730505600,
+			"end_time": 1730592000,
+			"results": [{
+				"object": "organization.costs.result",
+				"amount": {"value": 0.04, "currency": "usd"},
+				"line_item": "gpt-4o, output",
+				"project_id": "proj_123"
+			}]
+		}],
+		"has_more": false,
+		"next_page": null
+	}`
+
+	// Create mock HTTP client
+	callCount := 0
+	mockClient := &MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			callCount++
+			if req.Method != "GET" {
+				t.Errorf("Expected method to be GET, got %s", req.Method)
+			}
+			if req.URL.Path != "/v1/organization/costs" {
+				t.Errorf("Expected path to be /v1/organization/costs, got %s", req.URL.Path)
+			}
+			query := req.URL.Query()
+			if query.Get("bucket_width") != "1d" {
+				t.Errorf("Expected 'bucket_width' query parameter to be '1d', got '%s'", query.Get("bucket_width"))
+			}
+			if !reflect.DeepEqual(query["project_ids[]"], []string{"proj_123"}) {
+				t.Errorf("Expected 'project_ids[]' query parameter to be [proj_123], got %v", query["project_ids[]"])
+			}
+			if !reflect.DeepEqual(query["group_by[]"], []string{"project_id", "line_item"}) {
+				t.Errorf("Expected 'group_by[]' query parameter to be [project_id line_item], got %v", query["group_by[]"])
+			}
+			body := firstPageBody
+			if callCount == 2 {
+				if query.Get("page") != "page_AAAAAGdGxdEiJdKOAAAAAGcqsYA=" {
+					t.Errorf("Expected 'page' query parameter to be the next page cursor, got '%s'", query.Get("page"))
+				}
+				body = secondPageBody
+			}
+			return &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(strings.NewReader(body)),
+			}, nil
+		},
+	}
+
+	// Create client
+	client := &Client{
+		APIKey:     "test-api-key",
+		HTTPClient: mockClient,
+		BaseURL:    "https://api.openai.com/v1/organization",
+	}
+
+	// Test ListCosts
+	buckets, err := client.ListCosts(context.Background(), CostsQuery{
+		StartTime:  time.Unix(1730419200, 0),
+		ProjectIDs: []string{"proj_123"},
+		GroupBy:    []string{GroupByProjectID, GroupByLineItem},
+	})
+
+	// Verify result
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(*buckets) != 2 {
+		t.Fatalf("Expected 2 buckets, got %d", len(*buckets))
+	}
+	expectedResult := CostResult{
+		Object:    "organization.costs.result",
+		Amount:    CostAmount{Value: 0.06, Currency: "usd"},
+		LineItem:  "gpt-4o, input",
+		ProjectID: "proj_123",
+	}
+	if !reflect.DeepEqual((*buckets)[0].Results[0], expectedResult) {
+		t.Errorf("Expected result to be %+v, got %+v", expectedResult, (*buckets)[0].Results[0])
+	}
+	if callCount != 2 {
+		t.Errorf("Expected 2 API calls, got %d", callCount)
+	}
+}
+
+func TestTotalCost(t *testing.T) {
+	buckets := []CostBucket{
+		{Results: []CostResult{
+			{Amount: CostAmount{Value: 1.5, Currency: "usd"}},
+			{Amount: CostAmount{Value: 0.25, Currency: "usd"}},
+		}},
+		{Results: []CostResult{
+			{Amount: CostAmount{Value: 2, Currency: "usd"}},
+		}},
+	}
+
+	totals := TotalCost(buckets)
+
+	if totals["usd"] != 3.75 {
+		t.Errorf("Expected total to be 3.75 usd, got %v", totals["usd"])
+	}
+}
@@ -0,0 +1,157 @@
+package client
+
+import (
+	"encoding/json"
+	"log/slog"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Kinds of schema drift reported by DriftDetector.
+const (
+	DriftUnknownField = "unknown_field" // The response has a field the client type does not declare
+	DriftMissingField = "missing_field" // The response lacks a field the client type expects
+)
+
+// Drift describes a difference between an API response and the type it was decoded into.
+type Drift struct {
+	Kind  string // DriftUnknownField or DriftMissingField
+	Type  string // Name of the decoded type, such as ServiceAccount
+	Path  string // Templated request path
+	Field string // Dotted JSON path of the field, such as api_key.value
+}
+
+// DriftDetector enables strict decoding of Project, ServiceAccount and list responses.
+// Unknown and missing fields are logged the first time they are seen and counted, but never fail a request.
+type DriftDetector struct {
+	mu      sync.Mutex
+	counts  map[Drift]int
+	onDrift func(Drift)
+}
+
+// NewDriftDetector creates a drift detector. onDrift, if not nil, is called for every drift found.
+func NewDriftDetector(onDrift func(Drift)) *DriftDetector {
+	return &DriftDetector{
+		counts:  make(map[Drift]int),
+		onDrift: onDrift,
+	}
+}
+
+// Counts returns how often each drift has been seen.
+func (d *DriftDetector) Counts() map[Drift]int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	counts := make(map[Drift]int, len(d.counts))
+	for drift, n := range d.counts {
+		counts[drift] = n
+	}
+	return counts
+}
+
+// decodeJSON unmarshals a response body into v and reports schema drift when strict decoding is enabled.
+// optional lists the dotted field paths the endpoint is not expected to return.
+func (c *Client) decodeJSON(path string, data []byte, v any, optional ...string) error {
+	if err := json.Unmarshal(data, v); err != nil {
+		return err
+	}
+	c.Drift.check(templatePath(path), data, v, optional)
+	return nil
+}
+
+// check compares the fields of a JSON document with the type it was decoded into.
+func (d *DriftDetector) check(path string, data []byte, v any, optional []string) {
+	if d == nil {
+		return
+	}
+	var doc any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return
+	}
+	t := reflect.TypeOf(v)
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	found := make(map[Drift]bool)
+	walkDrift(t, doc, "", optional, func(kind string, field string) {
+		found[Drift{Kind: kind, Type: t.Name(), Path: path, Field: field}] = true
+	})
+	for drift := range found {
+		d.record(drift)
+	}
+}
+
+// record counts a drift, logging it the first time it is seen.
+func (d *DriftDetector) record(drift Drift) {
+	d.mu.Lock()
+	d.counts[drift]++
+	first := d.counts[drift] == 1
+	d.mu.Unlock()
+	if first {
+		slog.Warn("openai api schema drift", "kind", drift.Kind, "type", drift.Type, "path", drift.Path, "field", drift.Field)
+	}
+	if d.onDrift != nil {
+		d.onDrift(drift)
+	}
+}
+
+// walkDrift reports the differences between the JSON value doc and the type t. Elements of
+// slices are checked against the element type under the same field path.
+func walkDrift(t reflect.Type, doc any, prefix string, optional []string, report func(kind string, field string)) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Slice:
+		items, ok := doc.([]any)
+		if !ok {
+			return
+		}
+		for _, item := range items {
+			walkDrift(t.Elem(), item, prefix, optional, report)
+		}
+	case reflect.Struct:
+		object, ok := doc.(map[string]any)
+		if !ok {
+			return
+		}
+		known := make(map[string]bool)
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			name, omitEmpty, ok := jsonFieldName(field)
+			if !ok {
+				continue
+			}
+			known[name] = true
+			value, present := object[name]
+			switch {
+			case present:
+				walkDrift(field.Type, value, prefix+name+".", optional, report)
+			case !omitEmpty && !slices.Contains(optional, prefix+name):
+				report(DriftMissingField, prefix+name)
+			}
+		}
+		for name := range object {
+			if !known[name] {
+				report(DriftUnknownField, prefix+name)
+			}
+		}
+	}
+}
+
+// jsonFieldName returns the JSON name of a struct field and whether it is omitted when empty.
+func jsonFieldName(field reflect.StructField) (string, bool, bool) {
+	if !field.IsExported() {
+		return "", false, false
+	}
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return "", false, false
+	}
+	name, options, _ := strings.Cut(tag, ",")
+	if name == "" {
+		name = field.Name
+	}
+	return name, strings.Contains(options, "omitempty"), true
+}
@@ -0,0 +1,117 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func newDriftTestClient(responseBody string) *Client {
+	return &Client{
+		APIKey: "test-api-key",
+		HTTPClient: &MockHTTPClient{
+			DoFunc: func(req *http.Request) (*http.Response, error) {
+				return &http.Response{
+					StatusCode: 200,
+					Body:       io.NopCloser(strings.NewReader(responseBody)),
+				}, nil
+			},
+		},
+		BaseURL: "https://api.openai.com/v1/organization",
+	}
+}
+
+func TestDrift_CreateServiceAccount(t *testing.T) {
+	// Test data: the key value moved and the service account gained a field
+	responseBody := `{
+		"object": "organization.project.service_account",
+		"id": "sa_123",
+		"name": "test-service-account",
+		"role": "member",
+		"created_at": 1617123456,
+		"owner": "user@example.com",
+		"api_key": {"object": "organization.project.service_account.api_key", "secret": "sk-test-key", "name": "Secret Key", "created_at": 1617123456, "id": "key_123"}
+	}`
+
+	// Create client
+	var reported []Drift
+	client := newDriftTestClient(responseBody)
+	client.Drift = NewDriftDetector(func(d Drift) { reported = append(reported, d) })
+
+	// Test CreateServiceAccount
+	sa, err := client.CreateServiceAccount(context.Background(), "proj_123", "test-service-account")
+
+	// Verify result
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if sa.ID != "sa_123" {
+		t.Errorf("Expected service account sa_123, got %+v", sa)
+	}
+	expected := map[Drift]int{
+		{Kind: DriftUnknownField, Type: "ServiceAccount", Path: "/projects/{id}/service_accounts", Field: "owner"}:          1,
+		{Kind: DriftUnknownField, Type: "ServiceAccount", Path: "/projects/{id}/service_accounts", Field: "api_key.secret"}: 1,
+		{Kind: DriftMissingField, Type: "ServiceAccount", Path: "/projects/{id}/service_accounts", Field: "api_key.value"}:  1,
+	}
+	if counts := client.Drift.Counts(); !reflect.DeepEqual(counts, expected) {
+		t.Errorf("Expected drift counts to be %v, got %v", expected, counts)
+	}
+	if len(reported) != 3 {
+		t.Errorf("Expected 3 reported drifts, got %d", len(reported))
+	}
+}
+
+func TestDrift_ListServiceAccounts(t *testing.T) {
+	// Test data: list entries carry no api_key, and one of them lacks a role
+	responseBody := `{
+		"object": "list",
+		"data": [
+			{"object": "organization.project.service_account", "id": "sa_1", "name": "a", "role": "member", "created_at": 1},
+			{"object": "organization.project.service_account", "id": "sa_2", "name": "b", "created_at": 2}
+		],
+		"first_id": "sa_1",
+		"last_id": "sa_2",
+		"has_more": false
+	}`
+
+	// Create client
+	client := newDriftTestClient(responseBody)
+	client.Drift = NewDriftDetector(nil)
+
+	// Test ListServiceAccounts twice
+	for range 2 {
+		if _, err := client.ListServiceAccounts(context.Background(), "proj_123"); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+
+	// Verify result
+	expected := map[Drift]int{
+		{Kind: DriftMissingField, Type: "ListServiceAccountResponse", Path: "/projects/{id}/service_accounts", Field: "data.role"}: 2,
+	}
+	if counts := client.Drift.Counts(); !reflect.DeepEqual(counts, expected) {
+		t.Errorf("Expected drift counts to be %v, got %v", expected, counts)
+	}
+}
+
+func TestDrift_Project(t *testing.T) {
+	// Test data
+	responseBody := `{"id": "proj_123", "object": "organization.project", "name": "test", "created_at": 1, "archived_at": null, "status": "active"}`
+
+	// Create client
+	client := newDriftTestClient(responseBody)
+	client.Drift = NewDriftDetector(nil)
+
+	// Test GetProjectByID
+	if _, err := client.GetProjectByID(context.Background(), "proj_123"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// Verify result: a null field is present, so nothing drifted
+	if counts := client.Drift.Counts(); len(counts) != 0 {
+		t.Errorf("Expected no drift, got %v", counts)
+	}
+}
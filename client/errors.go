@@ -0,0 +1,83 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Sentinel errors matched by APIError through errors.Is.
+var (
+	ErrNotFound      = errors.New("not found")
+	ErrRateLimited   = errors.New("rate limited")
+	ErrUnauthorized  = errors.New("unauthorized")
+	ErrConflict      = errors.New("conflict")
+	ErrQuotaExceeded = errors.New("quota exceeded")
+)
+
+// APIError represents an error returned by the OpenAI API.
+type APIError struct {
+	StatusCode int           // HTTP status code
+	Message    string        // Error message, or the raw response body if it is not an error envelope
+	Type       string        // Error type from the error envelope
+	Code       string        // Error code from the error envelope
+	Param      string        // Request parameter the error relates to
+	Body       string        // Raw response body
+	RetryAfter time.Duration // Delay requested by the Retry-After header, if any
+}
+
+// errorEnvelope represents the error body returned by the OpenAI API.
+type errorEnvelope struct {
+	Error *struct {
+		Message string  `json:"message"`
+		Type    string  `json:"type"`
+		Code    *string `json:"code"`
+		Param   *string `json:"param"`
+	} `json:"error"`
+}
+
+// newAPIError builds an APIError from a response, decoding the error envelope when present.
+func newAPIError(statusCode int, body []byte) *APIError {
+	apiErr := &APIError{
+		StatusCode: statusCode,
+		Message:    string(body),
+		Body:       string(body),
+	}
+	var envelope errorEnvelope
+	if err := json.Unmarshal(body, &envelope); err != nil || envelope.Error == nil {
+		return apiErr
+	}
+	apiErr.Message = envelope.Error.Message
+	apiErr.Type = envelope.Error.Type
+	if envelope.Error.Code != nil {
+		apiErr.Code = *envelope.Error.Code
+	}
+	if envelope.Error.Param != nil {
+		apiErr.Param = *envelope.Error.Param
+	}
+	return apiErr
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("receive api response: %s (status code: %d)", e.Message, e.StatusCode)
+}
+
+// Is reports whether the error matches one of the package's sentinel errors.
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized || e.StatusCode == http.StatusForbidden
+	case ErrConflict:
+		return e.StatusCode == http.StatusConflict || strings.Contains(e.Code, "already_exists")
+	case ErrQuotaExceeded:
+		return e.Code == "insufficient_quota" || e.Type == "insufficient_quota"
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests && !e.Is(ErrQuotaExceeded)
+	}
+	return false
+}
@@ -0,0 +1,105 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestNewAPIError_Envelope(t *testing.T) {
+	body := `{"error":{"message":"Project not found","type":"invalid_request_error","code":"not_found","param":"project_id"}}`
+
+	apiErr := newAPIError(http.StatusNotFound, []byte(body))
+
+	if apiErr.Message != "Project not found" {
+		t.Errorf("Expected message to be 'Project not found', got '%s'", apiErr.Message)
+	}
+	if apiErr.Type != "invalid_request_error" {
+		t.Errorf("Expected type to be 'invalid_request_error', got '%s'", apiErr.Type)
+	}
+	if apiErr.Code != "not_found" {
+		t.Errorf("Expected code to be 'not_found', got '%s'", apiErr.Code)
+	}
+	if apiErr.Param != "project_id" {
+		t.Errorf("Expected param to be 'project_id', got '%s'", apiErr.Param)
+	}
+	if apiErr.Body != body {
+		t.Errorf("Expected body to be '%s', got '%s'", body, apiErr.Body)
+	}
+}
+
+func TestNewAPIError_NullFields(t *testing.T) {
+	body := `{"error":{"message":"Invalid key","type":"invalid_request_error","code":null,"param":null}}`
+
+	apiErr := newAPIError(http.StatusUnauthorized, []byte(body))
+
+	if apiErr.Message != "Invalid key" {
+		t.Errorf("Expected message to be 'Invalid key', got '%s'", apiErr.Message)
+	}
+	if apiErr.Code != "" || apiErr.Param != "" {
+		t.Errorf("Expected empty code and param, got '%s' and '%s'", apiErr.Code, apiErr.Param)
+	}
+}
+
+func TestNewAPIError_RawBody(t *testing.T) {
+	apiErr := newAPIError(http.StatusBadGateway, []byte("<html>Bad Gateway</html>"))
+
+	if apiErr.Message != "<html>Bad Gateway</html>" {
+		t.Errorf("Expected message to be the raw body, got '%s'", apiErr.Message)
+	}
+	if apiErr.Type != "" || apiErr.Code != "" {
+		t.Errorf("Expected empty type and code, got '%s' and '%s'", apiErr.Type, apiErr.Code)
+	}
+}
+
+func TestAPIError_Is(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      *APIError
+		target   error
+		expected bool
+	}{
+		{name: "Not found", err: &APIError{StatusCode: 404}, target: ErrNotFound, expected: true},
+		{name: "Unauthorized", err: &APIError{StatusCode: 401}, target: ErrUnauthorized, expected: true},
+		{name: "Forbidden", err: &APIError{StatusCode: 403}, target: ErrUnauthorized, expected: true},
+		{name: "Conflict status", err: &APIError{StatusCode: 409}, target: ErrConflict, expected: true},
+		{name: "Conflict code", err: &APIError{StatusCode: 400, Code: "project_already_exists"}, target: ErrConflict, expected: true},
+		{name: "Rate limited", err: &APIError{StatusCode: 429, Code: "rate_limit_exceeded"}, target: ErrRateLimited, expected: true},
+		{name: "Quota exceeded", err: &APIError{StatusCode: 429, Code: "insufficient_quota"}, target: ErrQuotaExceeded, expected: true},
+		{name: "Quota exceeded is not rate limited", err: &APIError{StatusCode: 429, Code: "insufficient_quota"}, target: ErrRateLimited, expected: false},
+		{name: "Server error", err: &APIError{StatusCode: 500}, target: ErrNotFound, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wrapped := fmt.Errorf("wrapped: %w", tt.err)
+			if errors.Is(wrapped, tt.target) != tt.expected {
+				t.Errorf("Expected errors.Is(%v, %v) to be %v", tt.err, tt.target, tt.expected)
+			}
+		})
+	}
+}
+
+func TestDoRequest_NoRetryOnQuotaExceeded(t *testing.T) {
+	callCount := 0
+	client := newRetryTestClient(func(req *http.Request) (*http.Response, error) {
+		callCount++
+		return &http.Response{
+			StatusCode: http.StatusTooManyRequests,
+			Body:       io.NopCloser(strings.NewReader(`{"error":{"message":"You exceeded your current quota","type":"insufficient_quota","code":"insufficient_quota"}}`)),
+		}, nil
+	})
+
+	_, err := client.doRequest(context.Background(), "GET", "/test-path", nil, nil)
+
+	if !errors.Is(err, ErrQuotaExceeded) {
+		t.Errorf("Expected ErrQuotaExceeded, got %v", err)
+	}
+	if callCount != 1 {
+		t.Errorf("Expected 1 API call, got %d", callCount)
+	}
+}
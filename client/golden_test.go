@@ -0,0 +1,106 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// Golden tests replay fixtures recorded from the real admin API into testdata/golden. Record them with
+//
+//	OPENAI_GOLDEN_RECORD=1 OPENAI_MANAGEMENT_KEY=... go test ./client -run Golden
+//
+// against an organization with a project named "personal". Tests whose fixture has not been
+// recorded yet are skipped rather than replayed from hand-written JSON.
+func newGoldenClient(t *testing.T, name string) *Client {
+	t.Helper()
+	path := filepath.Join("testdata", "golden", name+".json")
+
+	if os.Getenv("OPENAI_GOLDEN_RECORD") == "" {
+		recorder, err := NewRecorder(path, ModeReplay, nil)
+		if errors.Is(err, fs.ErrNotExist) {
+			t.Skipf("Fixture %s has not been recorded", path)
+		}
+		if err != nil {
+			t.Fatalf("Failed to load fixture: %v", err)
+		}
+		return NewClient("test-api-key", recorder)
+	}
+
+	recorder, err := NewRecorder(path, ModeRecord, http.DefaultClient)
+	if err != nil {
+		t.Fatalf("Failed to create recorder: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := recorder.Save(); err != nil {
+			t.Errorf("Failed to save fixture: %v", err)
+		}
+	})
+	return NewClient(os.Getenv("OPENAI_MANAGEMENT_KEY"), recorder)
+}
+
+func TestGolden_ServiceAccountLifecycle(t *testing.T) {
+	client := newGoldenClient(t, "service_account_lifecycle")
+	ctx := context.Background()
+
+	// Look up the project
+	project, found, err := client.GetProject(ctx, "personal")
+	if err != nil || !found {
+		t.Fatalf("Expected project to be found, got found=%v err=%v", found, err)
+	}
+	if project.ID == "" || project.Status == "" || project.CreatedAt == 0 {
+		t.Errorf("Expected project fields to be populated, got %+v", project)
+	}
+
+	// Create a service account and check the embedded API key
+	sa, err := client.CreateServiceAccount(ctx, project.ID, "user@example.com")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if sa.ID == "" || sa.CreatedAt == 0 || sa.Role == "" {
+		t.Errorf("Expected service account fields to be populated, got %+v", sa)
+	}
+	if sa.APIKey.ID == "" || sa.APIKey.Value == "" {
+		t.Errorf("Expected service account to include an API key, got %+v", sa.APIKey)
+	}
+
+	// List service accounts
+	serviceAccounts, err := client.ListServiceAccounts(ctx, project.ID)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	listed := false
+	for _, s := range *serviceAccounts {
+		listed = listed || s.ID == sa.ID
+	}
+	if !listed {
+		t.Errorf("Expected service account %s to be listed, got %+v", sa.ID, *serviceAccounts)
+	}
+
+	// Delete the service account
+	deleted, err := client.DeleteServiceAccount(ctx, project.ID, sa.ID)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !deleted.Deleted || deleted.ID != sa.ID {
+		t.Errorf("Expected service account %s to be deleted, got %+v", sa.ID, deleted)
+	}
+}
+
+func TestGolden_ProjectNotFound(t *testing.T) {
+	client := newGoldenClient(t, "project_not_found")
+
+	_, err := client.GetProjectByID(context.Background(), "proj_missing")
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Expected not found *APIError, got %v", err)
+	}
+	if apiErr.Message == "" || apiErr.Type == "" {
+		t.Errorf("Expected error envelope to be decoded, got %+v", apiErr)
+	}
+}
@@ -0,0 +1,132 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"net/url"
+	"strconv"
+)
+
+// Invite represents an invitation to join the OpenAI organization.
+type Invite struct {
+	ID         string          `json:"id"`
+	Object     string          `json:"object"`
+	Email      string          `json:"email"`
+	Role       string          `json:"role"`
+	Status     string          `json:"status"`
+	InvitedAt  int64           `json:"invited_at"`
+	ExpiresAt  int64           `json:"expires_at"`
+	AcceptedAt *int64          `json:"accepted_at"`
+	Projects   []InviteProject `json:"projects,omitempty"`
+}
+
+// InviteProject represents a project an invited user is granted access to.
+type InviteProject struct {
+	ID   string `json:"id"`
+	Role string `json:"role"`
+}
+
+// CreateInviteRequest holds the parameters for inviting a user to the organization.
+type CreateInviteRequest struct {
+	Email    string          `json:"email"`
+	Role     string          `json:"role"`
+	Projects []InviteProject `json:"projects,omitempty"`
+}
+
+// ListInviteResponse represents the response from the list invites API.
+type ListInviteResponse struct {
+	Object  string   `json:"object"`
+	Data    []Invite `json:"data"`
+	FirstID string   `json:"first_id"`
+	LastID  string   `json:"last_id"`
+	HasMore bool     `json:"has_more"`
+}
+
+// DeletedInviteResponse represents the response from the delete invite API.
+type DeletedInviteResponse struct {
+	Object  string `json:"object"`
+	ID      string `json:"id"`
+	Deleted bool   `json:"deleted"`
+}
+
+// ListInvites retrieves all pending and past invites of the organization.
+func (c *Client) ListInvites(ctx context.Context) (*[]Invite, error) {
+	return collect(c.Invites(ctx))
+}
+
+// Invites returns an iterator over all pending and past invites of the organization.
+func (c *Client) Invites(ctx context.Context) iter.Seq2[Invite, error] {
+	const pageSize = 100
+	return paginate(func(after string) ([]Invite, string, bool, error) {
+		resp, err := c.listInvites(ctx, after, pageSize)
+		if err != nil {
+			return nil, "", false, fmt.Errorf("get invite list: %w", err)
+		}
+		return resp.Data, resp.LastID, resp.HasMore, nil
+	})
+}
+
+// listInvites retrieves a page of invites with pagination options.
+func (c *Client) listInvites(ctx context.Context, after string, limit int) (*ListInviteResponse, error) {
+	query := url.Values{}
+	if after != "" {
+		query.Set("after", after)
+	}
+	if limit > 0 {
+		query.Set("limit", strconv.Itoa(limit))
+	}
+
+	respBody, err := c.doRequest(ctx, "GET", "/invites", query, nil)
+	if err != nil {
+		return nil, fmt.Errorf("execute request: %w", err)
+	}
+	var result ListInviteResponse
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		return nil, fmt.Errorf("unmarshal json: %w", err)
+	}
+	return &result, nil
+}
+
+// CreateInvite invites a user to the organization and optionally to projects.
+func (c *Client) CreateInvite(ctx context.Context, invite CreateInviteRequest) (*Invite, error) {
+	respBody, err := c.doRequest(ctx, "POST", "/invites", nil, invite)
+	if err != nil {
+		return nil, fmt.Errorf("create invite: %w", err)
+	}
+	var result Invite
+	err = json.Unmarshal(respBody, &result)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal json: %w", err)
+	}
+	return &result, nil
+}
+
+// GetInvite retrieves an invite by ID.
+func (c *Client) GetInvite(ctx context.Context, inviteID string) (*Invite, error) {
+	respBody, err := c.doRequest(ctx, "GET", fmt.Sprintf("/invites/%s", inviteID), nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("get invite: %w", err)
+	}
+	var result Invite
+	err = json.Unmarshal(respBody, &result)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal json: %w", err)
+	}
+	return &result, nil
+}
+
+// DeleteInvite revokes a pending invite.
+func (c *Client) DeleteInvite(ctx context.Context, inviteID string) (*DeletedInviteResponse, error) {
+	respBody, err := c.doRequest(ctx, "DELETE", fmt.Sprintf("/invites/%s", inviteID), nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("delete invite: %w", err)
+	}
+	var result DeletedInviteResponse
+	err = json.Unmarshal(respBody, &result)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal json: %w", err)
+	}
+	return &result, nil
+}
@@ -0,0 +1,118 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// Priority classifies requests competing for the client-side rate limit.
+type Priority int
+
+const (
+	PriorityInteractive Priority = iota // Requests a user is waiting on, such as key issuance
+	PriorityBackground                  // Requests from background work, such as cleanup
+)
+
+// ErrLimiterDeadline is returned when the context deadline expires before a token becomes available.
+var ErrLimiterDeadline = errors.New("rate limiter wait exceeds context deadline")
+
+type priorityKey struct{}
+
+// WithPriority marks requests made with the returned context with the given priority.
+// Requests without a priority are treated as interactive.
+func WithPriority(ctx context.Context, priority Priority) context.Context {
+	return context.WithValue(ctx, priorityKey{}, priority)
+}
+
+// PriorityFromContext returns the priority stored in the context, defaulting to PriorityInteractive.
+func PriorityFromContext(ctx context.Context) Priority {
+	priority, ok := ctx.Value(priorityKey{}).(Priority)
+	if !ok {
+		return PriorityInteractive
+	}
+	return priority
+}
+
+// Limiter is a token bucket shared by every request of a Client.
+// Background requests only take a token when no interactive request is waiting.
+type Limiter struct {
+	mu          sync.Mutex
+	rate        float64 // Tokens added per second
+	burst       float64 // Maximum number of stored tokens
+	tokens      float64
+	last        time.Time
+	interactive int // Number of interactive requests waiting for a token
+}
+
+// NewLimiter creates a limiter allowing rate requests per second with bursts of up to burst requests.
+// A non-positive rate disables limiting.
+func NewLimiter(rate float64, burst int) *Limiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &Limiter{
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+	}
+}
+
+// Wait blocks until a token is available for a request with the given priority.
+// It returns an error if the context is done or its deadline expires before a token is available.
+func (l *Limiter) Wait(ctx context.Context, priority Priority) error {
+	if l == nil || l.rate <= 0 {
+		return nil
+	}
+
+	if priority == PriorityInteractive {
+		l.mu.Lock()
+		l.interactive++
+		l.mu.Unlock()
+		defer func() {
+			l.mu.Lock()
+			l.interactive--
+			l.mu.Unlock()
+		}()
+	}
+
+	for {
+		delay, ok := l.reserve(priority)
+		if ok {
+			return nil
+		}
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+			return ErrLimiterDeadline
+		}
+		if !sleep(ctx, delay) {
+			return ctx.Err()
+		}
+	}
+}
+
+// reserve takes a token if one is available, otherwise it returns how long to wait before trying again.
+func (l *Limiter) reserve(priority Priority) (time.Duration, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	if !l.last.IsZero() {
+		l.tokens += now.Sub(l.last).Seconds() * l.rate
+		if l.tokens > l.burst {
+			l.tokens = l.burst
+		}
+	}
+	l.last = now
+
+	interval := time.Duration(float64(time.Second) / l.rate)
+	if priority == PriorityBackground && l.interactive > 0 {
+		// Yield to waiting interactive requests and check again after the next refill.
+		return interval, false
+	}
+	if l.tokens >= 1 {
+		l.tokens--
+		return 0, true
+	}
+	return time.Duration((1 - l.tokens) * float64(interval)), false
+}
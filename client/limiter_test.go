@@ -0,0 +1,135 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestLimiter_Burst(t *testing.T) {
+	limiter := NewLimiter(1, 3)
+
+	// Burst tokens are available immediately
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if err := limiter.Wait(context.Background(), PriorityInteractive); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed > 100*time.Millisecond {
+		t.Errorf("Expected burst requests to pass without waiting, took %v", elapsed)
+	}
+}
+
+func TestLimiter_DeadlineTooShort(t *testing.T) {
+	limiter := NewLimiter(1, 1)
+	if err := limiter.Wait(context.Background(), PriorityInteractive); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// The next token arrives in about a second
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	err := limiter.Wait(ctx, PriorityInteractive)
+
+	// Verify result
+	if !errors.Is(err, ErrLimiterDeadline) {
+		t.Errorf("Expected error to be %v, got %v", ErrLimiterDeadline, err)
+	}
+}
+
+func TestLimiter_InteractiveBeforeBackground(t *testing.T) {
+	limiter := NewLimiter(50, 1)
+	if err := limiter.Wait(context.Background(), PriorityInteractive); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// Start background requests first, then an interactive one
+	var mu sync.Mutex
+	var order []Priority
+	var wg sync.WaitGroup
+	wait := func(priority Priority) {
+		defer wg.Done()
+		if err := limiter.Wait(context.Background(), priority); err != nil {
+			t.Errorf("Unexpected error: %v", err)
+			return
+		}
+		mu.Lock()
+		order = append(order, priority)
+		mu.Unlock()
+	}
+	wg.Add(3)
+	go wait(PriorityBackground)
+	go wait(PriorityBackground)
+	time.Sleep(time.Millisecond)
+	go wait(PriorityInteractive)
+	wg.Wait()
+
+	// Verify result
+	if len(order) != 3 {
+		t.Fatalf("Expected 3 requests to pass, got %d", len(order))
+	}
+	if order[2] == PriorityInteractive {
+		t.Errorf("Expected interactive request to pass before the last background request, got order %v", order)
+	}
+}
+
+func TestLimiter_Nil(t *testing.T) {
+	var limiter *Limiter
+	if err := limiter.Wait(context.Background(), PriorityBackground); err != nil {
+		t.Errorf("Expected nil limiter not to block, got %v", err)
+	}
+}
+
+func TestWithPriority(t *testing.T) {
+	if p := PriorityFromContext(context.Background()); p != PriorityInteractive {
+		t.Errorf("Expected default priority to be interactive, got %v", p)
+	}
+	ctx := WithPriority(context.Background(), PriorityBackground)
+	if p := PriorityFromContext(ctx); p != PriorityBackground {
+		t.Errorf("Expected priority to be background, got %v", p)
+	}
+}
+
+func TestDoRequest_LimiterDeadline(t *testing.T) {
+	// Create mock HTTP client
+	callCount := 0
+	mockClient := &MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			callCount++
+			return &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(strings.NewReader(`{}`)),
+			}, nil
+		},
+	}
+
+	// Create client with a single token per second
+	client := &Client{
+		APIKey:     "test-api-key",
+		HTTPClient: mockClient,
+		BaseURL:    "https://api.openai.com/v1/organization",
+		Limiter:    NewLimiter(1, 1),
+	}
+
+	// Test doRequest
+	if _, err := client.doRequest(context.Background(), "GET", "/test-path", nil, nil); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err := client.doRequest(ctx, "GET", "/test-path", nil, nil)
+
+	// Verify result
+	if !errors.Is(err, ErrLimiterDeadline) {
+		t.Errorf("Expected error to be %v, got %v", ErrLimiterDeadline, err)
+	}
+	if callCount != 1 {
+		t.Errorf("Expected 1 API call, got %d", callCount)
+	}
+}
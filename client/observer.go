@@ -0,0 +1,88 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Observer receives a RequestInfo after every request made by a Client, including retried ones.
+type Observer interface {
+	ObserveRequest(ctx context.Context, info RequestInfo)
+}
+
+// RequestInfo describes a completed request to the OpenAI API.
+type RequestInfo struct {
+	Method     string        // HTTP method
+	Path       string        // Templated path such as /projects/{id}/service_accounts
+	StatusCode int           // Status code of the final attempt; zero when no response was received
+	Latency    time.Duration // Time from the first attempt to the result, including backoff and rate limiting
+	Retries    int           // Number of attempts after the first
+	ErrorClass string        // Class of the returned error; empty on success
+}
+
+// Error classes reported in RequestInfo.
+const (
+	ErrorClassCanceled      = "canceled"
+	ErrorClassTimeout       = "timeout"
+	ErrorClassTransport     = "transport"
+	ErrorClassRateLimited   = "rate_limited"
+	ErrorClassQuotaExceeded = "quota_exceeded"
+	ErrorClassClient        = "client_error"
+	ErrorClassServer        = "server_error"
+)
+
+// idCollections are the path segments that are followed by a resource ID.
+var idCollections = map[string]bool{
+	"projects":         true,
+	"service_accounts": true,
+	"api_keys":         true,
+	"rate_limits":      true,
+	"users":            true,
+	"invites":          true,
+	"admin_api_keys":   true,
+}
+
+// templatePath replaces the resource IDs in an API path with {id}.
+func templatePath(path string) string {
+	segments := strings.Split(path, "/")
+	for i := 1; i < len(segments); i++ {
+		if idCollections[segments[i-1]] && segments[i] != "" {
+			segments[i] = "{id}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// classifyError returns the error class reported to observers for a request error.
+func classifyError(err error) string {
+	if err == nil {
+		return ""
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		switch {
+		case errors.Is(apiErr, ErrQuotaExceeded):
+			return ErrorClassQuotaExceeded
+		case apiErr.StatusCode == http.StatusTooManyRequests:
+			return ErrorClassRateLimited
+		case apiErr.StatusCode >= 500:
+			return ErrorClassServer
+		default:
+			return ErrorClassClient
+		}
+	}
+	var netErr net.Error
+	switch {
+	case errors.Is(err, context.Canceled):
+		return ErrorClassCanceled
+	case errors.Is(err, context.DeadlineExceeded), errors.Is(err, ErrLimiterDeadline):
+		return ErrorClassTimeout
+	case errors.As(err, &netErr) && netErr.Timeout():
+		return ErrorClassTimeout
+	}
+	return ErrorClassTransport
+}
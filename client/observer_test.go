@@ -0,0 +1,140 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// recordingObserver collects the RequestInfo values it is given.
+type recordingObserver struct {
+	mu    sync.Mutex
+	infos []RequestInfo
+}
+
+func (o *recordingObserver) ObserveRequest(ctx context.Context, info RequestInfo) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.infos = append(o.infos, info)
+}
+
+func TestTemplatePath(t *testing.T) {
+	tests := []struct {
+		path     string
+		expected string
+	}{
+		{"/projects", "/projects"},
+		{"/projects/proj_123", "/projects/{id}"},
+		{"/projects/proj_123/archive", "/projects/{id}/archive"},
+		{"/projects/proj_123/service_accounts", "/projects/{id}/service_accounts"},
+		{"/projects/proj_123/service_accounts/svc_acct_456", "/projects/{id}/service_accounts/{id}"},
+		{"/projects/proj_123/api_keys/key_456", "/projects/{id}/api_keys/{id}"},
+		{"/projects/proj_123/rate_limits/rl_456", "/projects/{id}/rate_limits/{id}"},
+		{"/users/user_123", "/users/{id}"},
+		{"/invites/invite_123", "/invites/{id}"},
+		{"/admin_api_keys/key_123", "/admin_api_keys/{id}"},
+		{"/usage/completions", "/usage/completions"},
+	}
+	for _, tt := range tests {
+		if got := templatePath(tt.path); got != tt.expected {
+			t.Errorf("templatePath(%q) = %q, want %q", tt.path, got, tt.expected)
+		}
+	}
+}
+
+func TestClassifyError(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected string
+	}{
+		{"nil", nil, ""},
+		{"not found", &APIError{StatusCode: http.StatusNotFound}, ErrorClassClient},
+		{"rate limited", &APIError{StatusCode: http.StatusTooManyRequests}, ErrorClassRateLimited},
+		{"quota exceeded", &APIError{StatusCode: http.StatusTooManyRequests, Code: "insufficient_quota"}, ErrorClassQuotaExceeded},
+		{"server error", &APIError{StatusCode: http.StatusBadGateway}, ErrorClassServer},
+		{"retried server error", &RetryError{Attempts: 3, Reason: RetryReasonExhausted, Err: &APIError{StatusCode: http.StatusServiceUnavailable}}, ErrorClassServer},
+		{"canceled", fmt.Errorf("execute http request: %w", context.Canceled), ErrorClassCanceled},
+		{"deadline", fmt.Errorf("execute http request: %w", context.DeadlineExceeded), ErrorClassTimeout},
+		{"limiter deadline", fmt.Errorf("wait for rate limiter: %w", ErrLimiterDeadline), ErrorClassTimeout},
+		{"transport", fmt.Errorf("execute http request: %w", errors.New("connection refused")), ErrorClassTransport},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := classifyError(tt.err); got != tt.expected {
+				t.Errorf("Expected error class to be %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestDoRequest_Observer(t *testing.T) {
+	// Create mock HTTP client
+	callCount := 0
+	client := newRetryTestClient(func(req *http.Request) (*http.Response, error) {
+		callCount++
+		if callCount == 1 {
+			return &http.Response{
+				StatusCode: http.StatusBadGateway,
+				Body:       io.NopCloser(strings.NewReader("Bad Gateway")),
+			}, nil
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(strings.NewReader(`{"ok":true}`)),
+		}, nil
+	})
+	observer := &recordingObserver{}
+	client.Observer = observer
+
+	// Test doRequest
+	if _, err := client.doRequest(context.Background(), "GET", "/projects/proj_123/service_accounts", nil, nil); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// Verify result
+	if len(observer.infos) != 1 {
+		t.Fatalf("Expected 1 observed request, got %d", len(observer.infos))
+	}
+	info := observer.infos[0]
+	if info.Method != "GET" || info.Path != "/projects/{id}/service_accounts" {
+		t.Errorf("Expected GET /projects/{id}/service_accounts, got %s %s", info.Method, info.Path)
+	}
+	if info.StatusCode != http.StatusOK || info.Retries != 1 || info.ErrorClass != "" {
+		t.Errorf("Expected status 200 after 1 retry without error, got %+v", info)
+	}
+	if info.Latency <= 0 {
+		t.Errorf("Expected a positive latency, got %v", info.Latency)
+	}
+}
+
+func TestDoRequest_ObserverError(t *testing.T) {
+	// Create mock HTTP client
+	client := newRetryTestClient(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusNotFound,
+			Body:       io.NopCloser(strings.NewReader(`{"error":{"message":"No such project"}}`)),
+		}, nil
+	})
+	observer := &recordingObserver{}
+	client.Observer = observer
+
+	// Test doRequest
+	if _, err := client.doRequest(context.Background(), "DELETE", "/projects/proj_123/service_accounts/sa_456", nil, nil); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Expected error to be %v, got %v", ErrNotFound, err)
+	}
+
+	// Verify result
+	if len(observer.infos) != 1 {
+		t.Fatalf("Expected 1 observed request, got %d", len(observer.infos))
+	}
+	info := observer.infos[0]
+	if info.Path != "/projects/{id}/service_accounts/{id}" || info.StatusCode != http.StatusNotFound || info.Retries != 0 || info.ErrorClass != ErrorClassClient {
+		t.Errorf("Expected a non-retried client error, got %+v", info)
+	}
+}
@@ -0,0 +1,47 @@
+package client
+
+import (
+	"iter"
+)
+
+// pageFetcher retrieves the page that follows cursor, returning its items, the cursor of the
+// next page and whether more pages exist. An empty cursor requests the first page.
+type pageFetcher[T any] func(cursor string) ([]T, string, bool, error)
+
+// paginate returns an iterator over every item of a cursor-paginated list endpoint.
+// Pages are fetched lazily, so callers that stop iterating early skip the remaining requests.
+// A failed page request is yielded as the final error.
+func paginate[T any](fetch pageFetcher[T]) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var cursor string
+		for {
+			items, next, hasMore, err := fetch(cursor)
+			if err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+			for _, item := range items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+			if !hasMore || next == "" {
+				return
+			}
+			cursor = next
+		}
+	}
+}
+
+// collect drains an iterator into a slice, stopping at the first error.
+func collect[T any](seq iter.Seq2[T, error]) (*[]T, error) {
+	var all []T
+	for item, err := range seq {
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, item)
+	}
+	return &all, nil
+}
@@ -0,0 +1,100 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestPaginate_StopsEarly(t *testing.T) {
+	// Test data
+	pages := map[string][]int{"": {1, 2}, "2": {3, 4}, "4": {5}}
+	fetched := 0
+
+	// Iterate until the first item of the second page
+	seq := paginate(func(cursor string) ([]int, string, bool, error) {
+		fetched++
+		next := map[string]string{"": "2", "2": "4"}[cursor]
+		return pages[cursor], next, next != "", nil
+	})
+	var got []int
+	for item, err := range seq {
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		got = append(got, item)
+		if item == 3 {
+			break
+		}
+	}
+
+	// Verify result
+	if len(got) != 3 {
+		t.Errorf("Expected 3 items, got %v", got)
+	}
+	if fetched != 2 {
+		t.Errorf("Expected 2 page requests, got %d", fetched)
+	}
+}
+
+func TestPaginate_Error(t *testing.T) {
+	// Test data
+	expectedError := errors.New("page error")
+
+	// Iterate over a fetcher that fails on the second page
+	seq := paginate(func(cursor string) ([]int, string, bool, error) {
+		if cursor == "" {
+			return []int{1}, "1", true, nil
+		}
+		return nil, "", false, expectedError
+	})
+	_, err := collect(seq)
+
+	// Verify result
+	if !errors.Is(err, expectedError) {
+		t.Errorf("Expected error to be %v, got %v", expectedError, err)
+	}
+}
+
+func TestGetProject_StopsAtFirstActiveMatch(t *testing.T) {
+	// Create mock HTTP client
+	callCount := 0
+	mockClient := &MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			callCount++
+			if after := req.URL.Query().Get("after"); after != "" {
+				t.Errorf("Expected only the first page to be requested, got after=%s", after)
+			}
+			return &http.Response{
+				StatusCode: 200,
+				Body: io.NopCloser(strings.NewReader(`{"object":"list","data":[` +
+					`{"id":"proj_1","name":"test-project","status":"active"}],` +
+					`"first_id":"proj_1","last_id":"proj_1","has_more":true}`)),
+			}, nil
+		},
+	}
+
+	// Create client
+	client := &Client{
+		APIKey:     "test-api-key",
+		HTTPClient: mockClient,
+		BaseURL:    "https://api.openai.com/v1/organization",
+	}
+
+	// Test GetProject
+	project, found, err := client.GetProject(context.Background(), "test-project")
+
+	// Verify result
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !found || project.ID != "proj_1" {
+		t.Errorf("Expected project proj_1 to be found, got %v (found: %v)", project, found)
+	}
+	if callCount != 1 {
+		t.Errorf("Expected 1 API call, got %d", callCount)
+	}
+}
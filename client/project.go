@@ -2,8 +2,8 @@ package client
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
+	"iter"
 	"net/url"
 	"strconv"
 )
@@ -18,6 +18,11 @@ type Project struct {
 	Status     string `json:"status"`
 }
 
+// IsArchived reports whether the project has been archived.
+func (p *Project) IsArchived() bool {
+	return p.Status == "archived" || p.ArchivedAt != nil
+}
+
 // ListProjectResponse represents the response from the list projects API.
 type ListProjectResponse struct {
 	Object  string    `json:"object"`
@@ -35,46 +40,113 @@ func (c *Client) CreateProject(ctx context.Context, name string) (*Project, erro
 		return nil, fmt.Errorf("create project: %w", err)
 	}
 	var project Project
-	err = json.Unmarshal(respBody, &project)
+	err = c.decodeJSON("/projects", respBody, &project)
 	if err != nil {
 		return nil, fmt.Errorf("unmarshal json: %w", err)
 	}
+	c.ProjectCache.Put(&project)
 	return &project, nil
 }
 
+// cachedProject returns the active project with the given name from ProjectCache, without calling the API.
+func (c *Client) cachedProject(projectName string) (*Project, bool) {
+	return c.ProjectCache.Get(projectName)
+}
+
 // GetProject retrieves a project by name, returning the project, a boolean indicating if it was found, and any error.
+// Active projects take precedence; if only archived projects match, the first of them is returned
+// so that callers can detect the archived state instead of creating a duplicate.
+// Active matches are served from ProjectCache when it is set.
 func (c *Client) GetProject(ctx context.Context, projectName string) (*Project, bool, error) {
-	projects, err := c.listProjects(ctx, false)
-	if err != nil {
-		return nil, false, fmt.Errorf("get project list: %w", err)
+	if project, ok := c.cachedProject(projectName); ok {
+		return project, true, nil
 	}
-	for _, project := range *projects {
-		if project.Name == projectName {
+	var archived *Project
+	for project, err := range c.Projects(ctx, true) {
+		if err != nil {
+			return nil, false, fmt.Errorf("get project list: %w", err)
+		}
+		if project.Name != projectName {
+			continue
+		}
+		if !project.IsArchived() {
+			c.ProjectCache.Put(&project)
 			return &project, true, nil
 		}
+		if archived == nil {
+			archived = &project
+		}
+	}
+	if archived != nil {
+		return archived, true, nil
 	}
 	return nil, false, nil
 }
 
+// GetProjectByID retrieves a project by ID.
+func (c *Client) GetProjectByID(ctx context.Context, projectID string) (*Project, error) {
+	path := fmt.Sprintf("/projects/%s", projectID)
+	respBody, err := c.doRequest(ctx, "GET", path, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("get project: %w", err)
+	}
+	var project Project
+	err = c.decodeJSON(path, respBody, &project)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal json: %w", err)
+	}
+	c.ProjectCache.Put(&project)
+	return &project, nil
+}
+
+// ModifyProject renames a project.
+func (c *Client) ModifyProject(ctx context.Context, projectID string, name string) (*Project, error) {
+	body := map[string]string{"name": name}
+	path := fmt.Sprintf("/projects/%s", projectID)
+	respBody, err := c.doRequest(WithIdempotent(ctx), "POST", path, nil, body)
+	if err != nil {
+		return nil, fmt.Errorf("modify project: %w", err)
+	}
+	var project Project
+	err = c.decodeJSON(path, respBody, &project)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal json: %w", err)
+	}
+	c.ProjectCache.Put(&project)
+	return &project, nil
+}
+
+// ArchiveProject archives a project. Archived projects cannot be used or updated.
+func (c *Client) ArchiveProject(ctx context.Context, projectID string) (*Project, error) {
+	path := fmt.Sprintf("/projects/%s/archive", projectID)
+	respBody, err := c.doRequest(WithIdempotent(ctx), "POST", path, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("archive project: %w", err)
+	}
+	var project Project
+	err = c.decodeJSON(path, respBody, &project)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal json: %w", err)
+	}
+	c.ProjectCache.Put(&project)
+	return &project, nil
+}
+
 // listProjects retrieves all projects, optionally including archived ones.
 func (c *Client) listProjects(ctx context.Context, includeArchived bool) (*[]Project, error) {
-	var allProjects []Project
-	var after string
-	const pageSize = 100
+	return collect(c.Projects(ctx, includeArchived))
+}
 
-	for {
+// Projects returns an iterator over all projects, optionally including archived ones.
+func (c *Client) Projects(ctx context.Context, includeArchived bool) iter.Seq2[Project, error] {
+	const pageSize = 100
+	return paginate(func(after string) ([]Project, string, bool, error) {
 		resp, err := c.listProject(ctx, after, pageSize, includeArchived)
 		if err != nil {
-			return nil, fmt.Errorf("get project list: %w", err)
-		}
-		allProjects = append(allProjects, resp.Data...)
-		if !resp.HasMore {
-			break
+			return nil, "", false, fmt.Errorf("get project list: %w", err)
 		}
-		after = resp.LastID
-	}
-
-	return &allProjects, nil
+		return resp.Data, resp.LastID, resp.HasMore, nil
+	})
 }
 
 // listProject retrieves a page of projects with pagination and filtering options.
@@ -95,7 +167,7 @@ func (c *Client) listProject(ctx context.Context, after string, limit int, inclu
 		return nil, fmt.Errorf("execute request: %w", err)
 	}
 	var result ListProjectResponse
-	if err := json.Unmarshal(respBody, &result); err != nil {
+	if err := c.decodeJSON("/projects", respBody, &result); err != nil {
 		return nil, fmt.Errorf("unmarshal json: %w", err)
 	}
 	return &result, nil
@@ -358,3 +358,267 @@ func TestListProjects_Pagination(t *testing.T) {
 		t.Errorf("Expected 2 API calls, got %d", callCount)
 	}
 }
+
+func TestGetProject_PrefersActiveOverArchived(t *testing.T) {
+	// Test data
+	projectName := "test-project"
+	archivedAt := int64(1617123999)
+	projects := []Project{
+		{
+			ID:         "proj_archived",
+			Object:     "project",
+			Name:       projectName,
+			CreatedAt:  1617123456,
+			ArchivedAt: &archivedAt,
+			Status:     "archived",
+		},
+		{
+			ID:        "proj_active",
+			Object:    "project",
+			Name:      projectName,
+			CreatedAt: 1617124000,
+			Status:    "active",
+		},
+	}
+	responseBody, _ := json.Marshal(ListProjectResponse{
+		Object:  "list",
+		Data:    projects,
+		FirstID: "proj_archived",
+		LastID:  "proj_active",
+		HasMore: false,
+	})
+
+	// Create mock HTTP client
+	mockClient := &MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			if req.URL.Query().Get("include_archived") != "true" {
+				t.Errorf("Expected 'include_archived' query parameter to be 'true', got '%s'", req.URL.Query().Get("include_archived"))
+			}
+			return &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(strings.NewReader(string(responseBody))),
+			}, nil
+		},
+	}
+
+	// Create client
+	client := &Client{
+		APIKey:     "test-api-key",
+		HTTPClient: mockClient,
+		BaseURL:    "https://api.openai.com/v1/organization",
+	}
+
+	// Test GetProject
+	project, found, err := client.GetProject(context.Background(), projectName)
+
+	// Verify result
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if !found {
+		t.Fatal("Expected project to be found")
+	}
+	if project.ID != "proj_active" {
+		t.Errorf("Expected project ID to be 'proj_active', got '%s'", project.ID)
+	}
+}
+
+func TestGetProject_OnlyArchived(t *testing.T) {
+	// Test data
+	projectName := "test-project"
+	archivedAt := int64(1617123999)
+	responseBody, _ := json.Marshal(ListProjectResponse{
+		Object: "list",
+		Data: []Project{
+			{
+				ID:         "proj_archived",
+				Object:     "project",
+				Name:       projectName,
+				CreatedAt:  1617123456,
+				ArchivedAt: &archivedAt,
+				Status:     "archived",
+			},
+		},
+		HasMore: false,
+	})
+
+	// Create mock HTTP client
+	mockClient := &MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(strings.NewReader(string(responseBody))),
+			}, nil
+		},
+	}
+
+	// Create client
+	client := &Client{
+		APIKey:     "test-api-key",
+		HTTPClient: mockClient,
+		BaseURL:    "https://api.openai.com/v1/organization",
+	}
+
+	// Test GetProject
+	project, found, err := client.GetProject(context.Background(), projectName)
+
+	// Verify result
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if !found {
+		t.Fatal("Expected archived project to be found")
+	}
+	if !project.IsArchived() {
+		t.Errorf("Expected project to be archived, got %+v", project)
+	}
+}
+
+func TestGetProjectByID(t *testing.T) {
+	// Test data
+	expectedProject := Project{
+		ID:        "proj_123",
+		Object:    "organization.project",
+		Name:      "test-project",
+		CreatedAt: 1617123456,
+		Status:    "active",
+	}
+	responseBody, _ := json.Marshal(expectedProject)
+
+	// Create mock HTTP client
+	mockClient := &MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			if req.Method != "GET" {
+				t.Errorf("Expected method to be GET, got %s", req.Method)
+			}
+			if req.URL.String() != "https://api.openai.com/v1/organization/projects/proj_123" {
+				t.Errorf("Expected URL to be %s, got %s", "https://api.openai.com/v1/organization/projects/proj_123", req.URL.String())
+			}
+			return &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(strings.NewReader(string(responseBody))),
+			}, nil
+		},
+	}
+
+	// Create client
+	client := &Client{
+		APIKey:     "test-api-key",
+		HTTPClient: mockClient,
+		BaseURL:    "https://api.openai.com/v1/organization",
+	}
+
+	// Test GetProjectByID
+	project, err := client.GetProjectByID(context.Background(), "proj_123")
+
+	// Verify result
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(*project, expectedProject) {
+		t.Errorf("Expected project to be %+v, got %+v", expectedProject, *project)
+	}
+}
+
+func TestModifyProject(t *testing.T) {
+	// Test data
+	expectedProject := Project{
+		ID:        "proj_123",
+		Object:    "organization.project",
+		Name:      "renamed-project",
+		CreatedAt: 1617123456,
+		Status:    "active",
+	}
+	responseBody, _ := json.Marshal(expectedProject)
+
+	// Create mock HTTP client
+	mockClient := &MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			if req.Method != "POST" {
+				t.Errorf("Expected method to be POST, got %s", req.Method)
+			}
+			if req.URL.String() != "https://api.openai.com/v1/organization/projects/proj_123" {
+				t.Errorf("Expected URL to be %s, got %s", "https://api.openai.com/v1/organization/projects/proj_123", req.URL.String())
+			}
+			body, _ := io.ReadAll(req.Body)
+			var requestBody map[string]string
+			if err := json.Unmarshal(body, &requestBody); err != nil {
+				t.Errorf("Failed to unmarshal request body: %v", err)
+				return nil, err
+			}
+			if requestBody["name"] != "renamed-project" {
+				t.Errorf("Expected request body to contain name=renamed-project, got %v", requestBody)
+			}
+			return &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(strings.NewReader(string(responseBody))),
+			}, nil
+		},
+	}
+
+	// Create client
+	client := &Client{
+		APIKey:     "test-api-key",
+		HTTPClient: mockClient,
+		BaseURL:    "https://api.openai.com/v1/organization",
+	}
+
+	// Test ModifyProject
+	project, err := client.ModifyProject(context.Background(), "proj_123", "renamed-project")
+
+	// Verify result
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(*project, expectedProject) {
+		t.Errorf("Expected project to be %+v, got %+v", expectedProject, *project)
+	}
+}
+
+func TestArchiveProject(t *testing.T) {
+	// Test data
+	archivedAt := int64(1617123999)
+	expectedProject := Project{
+		ID:         "proj_123",
+		Object:     "organization.project",
+		Name:       "test-project",
+		CreatedAt:  1617123456,
+		ArchivedAt: &archivedAt,
+		Status:     "archived",
+	}
+	responseBody, _ := json.Marshal(expectedProject)
+
+	// Create mock HTTP client
+	mockClient := &MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			if req.Method != "POST" {
+				t.Errorf("Expected method to be POST, got %s", req.Method)
+			}
+			if req.URL.String() != "https://api.openai.com/v1/organization/projects/proj_123/archive" {
+				t.Errorf("Expected URL to be %s, got %s", "https://api.openai.com/v1/organization/projects/proj_123/archive", req.URL.String())
+			}
+			return &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(strings.NewReader(string(responseBody))),
+			}, nil
+		},
+	}
+
+	// Create client
+	client := &Client{
+		APIKey:     "test-api-key",
+		HTTPClient: mockClient,
+		BaseURL:    "https://api.openai.com/v1/organization",
+	}
+
+	// Test ArchiveProject
+	project, err := client.ArchiveProject(context.Background(), "proj_123")
+
+	// Verify result
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if !project.IsArchived() {
+		t.Errorf("Expected project to be archived, got %+v", *project)
+	}
+}
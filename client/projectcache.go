@@ -0,0 +1,101 @@
+package client
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ProjectCache caches active projects by name so that GetProject does not walk every page of /projects.
+// Entries expire after the TTL and are dropped as soon as the API reports the project as archived or missing.
+type ProjectCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[string]projectCacheEntry
+}
+
+type projectCacheEntry struct {
+	project   Project
+	expiresAt time.Time
+}
+
+// NewProjectCache creates a project cache whose entries live for ttl.
+func NewProjectCache(ttl time.Duration) *ProjectCache {
+	return &ProjectCache{
+		ttl:     ttl,
+		entries: make(map[string]projectCacheEntry),
+	}
+}
+
+// Get returns the cached project with the given name, if present and not expired.
+func (pc *ProjectCache) Get(name string) (*Project, bool) {
+	if pc == nil {
+		return nil, false
+	}
+	pc.mu.Lock()
+	defer pc.mu.Unlock()
+	entry, ok := pc.entries[name]
+	if !ok {
+		return nil, false
+	}
+	if time.Now().After(entry.expiresAt) {
+		delete(pc.entries, name)
+		return nil, false
+	}
+	project := entry.project
+	return &project, true
+}
+
+// Put caches an active project under its name. Archived projects are removed instead.
+func (pc *ProjectCache) Put(project *Project) {
+	if pc == nil || pc.ttl <= 0 || project == nil {
+		return
+	}
+	if project.IsArchived() {
+		pc.Invalidate(project.ID)
+		return
+	}
+	pc.mu.Lock()
+	defer pc.mu.Unlock()
+	for name, entry := range pc.entries {
+		if entry.project.ID == project.ID && name != project.Name {
+			delete(pc.entries, name)
+		}
+	}
+	pc.entries[project.Name] = projectCacheEntry{
+		project:   *project,
+		expiresAt: time.Now().Add(pc.ttl),
+	}
+}
+
+// Invalidate removes every entry for the project with the given ID.
+func (pc *ProjectCache) Invalidate(projectID string) {
+	if pc == nil {
+		return
+	}
+	pc.mu.Lock()
+	defer pc.mu.Unlock()
+	for name, entry := range pc.entries {
+		if entry.project.ID == projectID {
+			delete(pc.entries, name)
+		}
+	}
+}
+
+// invalidateOnError drops the project addressed by a /projects/{id} path when the API reports it
+// as missing or archived.
+func (pc *ProjectCache) invalidateOnError(path string, err error) {
+	if pc == nil {
+		return
+	}
+	rest, ok := strings.CutPrefix(path, "/projects/")
+	if !ok {
+		return
+	}
+	projectID, _, _ := strings.Cut(rest, "/")
+	var apiErr *APIError
+	if errors.Is(err, ErrNotFound) || (errors.As(err, &apiErr) && strings.Contains(strings.ToLower(apiErr.Message), "archived")) {
+		pc.Invalidate(projectID)
+	}
+}
@@ -0,0 +1,123 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestProjectCache_PutGet(t *testing.T) {
+	cache := NewProjectCache(time.Minute)
+	cache.Put(&Project{ID: "proj_1", Name: "test-project", Status: "active"})
+
+	project, ok := cache.Get("test-project")
+	if !ok || project.ID != "proj_1" {
+		t.Errorf("Expected cached project proj_1, got %v (ok: %v)", project, ok)
+	}
+
+	// Renaming the project drops the old name
+	cache.Put(&Project{ID: "proj_1", Name: "renamed-project", Status: "active"})
+	if _, ok := cache.Get("test-project"); ok {
+		t.Error("Expected old project name to be dropped after rename")
+	}
+
+	// Archiving the project drops it
+	archivedAt := int64(1711471533)
+	cache.Put(&Project{ID: "proj_1", Name: "renamed-project", Status: "archived", ArchivedAt: &archivedAt})
+	if _, ok := cache.Get("renamed-project"); ok {
+		t.Error("Expected archived project to be dropped")
+	}
+}
+
+func TestProjectCache_Expired(t *testing.T) {
+	cache := NewProjectCache(time.Nanosecond)
+	cache.Put(&Project{ID: "proj_1", Name: "test-project", Status: "active"})
+	time.Sleep(time.Millisecond)
+
+	if _, ok := cache.Get("test-project"); ok {
+		t.Error("Expected expired entry not to be returned")
+	}
+}
+
+func TestProjectCache_InvalidateOnError(t *testing.T) {
+	tests := []struct {
+		name        string
+		path        string
+		err         error
+		invalidated bool
+	}{
+		{name: "Not found", path: "/projects/proj_1/service_accounts", err: &APIError{StatusCode: 404}, invalidated: true},
+		{name: "Archived", path: "/projects/proj_1", err: &APIError{StatusCode: 400, Message: "Project is archived"}, invalidated: true},
+		{name: "Server error", path: "/projects/proj_1", err: &APIError{StatusCode: 500}, invalidated: false},
+		{name: "Other project", path: "/projects/proj_2", err: &APIError{StatusCode: 404}, invalidated: false},
+		{name: "Other endpoint", path: "/users/user_1", err: &APIError{StatusCode: 404}, invalidated: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cache := NewProjectCache(time.Minute)
+			cache.Put(&Project{ID: "proj_1", Name: "test-project", Status: "active"})
+
+			cache.invalidateOnError(tt.path, tt.err)
+
+			_, ok := cache.Get("test-project")
+			if ok == tt.invalidated {
+				t.Errorf("Expected invalidated to be %v, got %v", tt.invalidated, !ok)
+			}
+		})
+	}
+}
+
+func TestGetProject_Cached(t *testing.T) {
+	// Create mock HTTP client
+	callCount := 0
+	mockClient := &MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			callCount++
+			if strings.HasSuffix(req.URL.Path, "/service_accounts") {
+				return &http.Response{
+					StatusCode: 404,
+					Body:       io.NopCloser(strings.NewReader(`{"error":{"message":"Project not found","type":"invalid_request_error"}}`)),
+				}, nil
+			}
+			return &http.Response{
+				StatusCode: 200,
+				Body: io.NopCloser(strings.NewReader(`{"object":"list","data":[` +
+					`{"id":"proj_1","name":"test-project","status":"active"}],` +
+					`"first_id":"proj_1","last_id":"proj_1","has_more":false}`)),
+			}, nil
+		},
+	}
+
+	// Create client
+	client := &Client{
+		APIKey:       "test-api-key",
+		HTTPClient:   mockClient,
+		BaseURL:      "https://api.openai.com/v1/organization",
+		ProjectCache: NewProjectCache(time.Minute),
+	}
+
+	// Second lookup is served from the cache
+	for i := 0; i < 2; i++ {
+		if _, found, err := client.GetProject(context.Background(), "test-project"); err != nil || !found {
+			t.Fatalf("Expected project to be found, got found=%v err=%v", found, err)
+		}
+	}
+	if callCount != 1 {
+		t.Errorf("Expected 1 API call, got %d", callCount)
+	}
+
+	// A 404 on the project drops the cached entry
+	if _, err := client.ListServiceAccounts(context.Background(), "proj_1"); err == nil {
+		t.Fatal("Expected error, got nil")
+	}
+	if _, _, err := client.GetProject(context.Background(), "test-project"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if callCount != 3 {
+		t.Errorf("Expected 3 API calls, got %d", callCount)
+	}
+}
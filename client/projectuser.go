@@ -0,0 +1,139 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"net/url"
+	"strconv"
+)
+
+// Project roles.
+const (
+	ProjectRoleOwner  = "owner"
+	ProjectRoleMember = "member"
+)
+
+// ProjectUser represents a user's membership in an OpenAI project.
+type ProjectUser struct {
+	ID      string `json:"id"`
+	Object  string `json:"object"`
+	Name    string `json:"name"`
+	Email   string `json:"email"`
+	Role    string `json:"role"`
+	AddedAt int64  `json:"added_at"`
+}
+
+// ListProjectUserResponse represents the response from the list project users API.
+type ListProjectUserResponse struct {
+	Object  string        `json:"object"`
+	Data    []ProjectUser `json:"data"`
+	FirstID string        `json:"first_id"`
+	LastID  string        `json:"last_id"`
+	HasMore bool          `json:"has_more"`
+}
+
+// DeletedProjectUserResponse represents the response from the delete project user API.
+type DeletedProjectUserResponse struct {
+	Object  string `json:"object"`
+	ID      string `json:"id"`
+	Deleted bool   `json:"deleted"`
+}
+
+// ListProjectUsers retrieves all users of a project.
+func (c *Client) ListProjectUsers(ctx context.Context, projectID string) (*[]ProjectUser, error) {
+	return collect(c.ProjectUsers(ctx, projectID))
+}
+
+// ProjectUsers returns an iterator over all users of a project.
+func (c *Client) ProjectUsers(ctx context.Context, projectID string) iter.Seq2[ProjectUser, error] {
+	const pageSize = 100
+	return paginate(func(after string) ([]ProjectUser, string, bool, error) {
+		resp, err := c.listProjectUsers(ctx, projectID, after, pageSize)
+		if err != nil {
+			return nil, "", false, fmt.Errorf("get project user list: %w", err)
+		}
+		return resp.Data, resp.LastID, resp.HasMore, nil
+	})
+}
+
+// listProjectUsers retrieves a page of project users with pagination options.
+func (c *Client) listProjectUsers(ctx context.Context, projectID string, after string, limit int) (*ListProjectUserResponse, error) {
+	query := url.Values{}
+	if after != "" {
+		query.Set("after", after)
+	}
+	if limit > 0 {
+		query.Set("limit", strconv.Itoa(limit))
+	}
+
+	path := fmt.Sprintf("/projects/%s/users", projectID)
+	respBody, err := c.doRequest(ctx, "GET", path, query, nil)
+	if err != nil {
+		return nil, fmt.Errorf("execute request: %w", err)
+	}
+	var result ListProjectUserResponse
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		return nil, fmt.Errorf("unmarshal json: %w", err)
+	}
+	return &result, nil
+}
+
+// AddProjectUser adds an organization user to a project with the given role.
+func (c *Client) AddProjectUser(ctx context.Context, projectID string, userID string, role string) (*ProjectUser, error) {
+	body := map[string]string{"user_id": userID, "role": role}
+	respBody, err := c.doRequest(ctx, "POST", fmt.Sprintf("/projects/%s/users", projectID), nil, body)
+	if err != nil {
+		return nil, fmt.Errorf("add project user: %w", err)
+	}
+	var user ProjectUser
+	err = json.Unmarshal(respBody, &user)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal json: %w", err)
+	}
+	return &user, nil
+}
+
+// GetProjectUser retrieves a user's membership in a project.
+func (c *Client) GetProjectUser(ctx context.Context, projectID string, userID string) (*ProjectUser, error) {
+	respBody, err := c.doRequest(ctx, "GET", fmt.Sprintf("/projects/%s/users/%s", projectID, userID), nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("get project user: %w", err)
+	}
+	var user ProjectUser
+	err = json.Unmarshal(respBody, &user)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal json: %w", err)
+	}
+	return &user, nil
+}
+
+// ModifyProjectUserRole changes a user's role in a project.
+func (c *Client) ModifyProjectUserRole(ctx context.Context, projectID string, userID string, role string) (*ProjectUser, error) {
+	body := map[string]string{"role": role}
+	respBody, err := c.doRequest(WithIdempotent(ctx), "POST", fmt.Sprintf("/projects/%s/users/%s", projectID, userID), nil, body)
+	if err != nil {
+		return nil, fmt.Errorf("modify project user: %w", err)
+	}
+	var user ProjectUser
+	err = json.Unmarshal(respBody, &user)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal json: %w", err)
+	}
+	return &user, nil
+}
+
+// DeleteProjectUser removes a user from a project.
+func (c *Client) DeleteProjectUser(ctx context.Context, projectID string, userID string) (*DeletedProjectUserResponse, error) {
+	respBody, err := c.doRequest(ctx, "DELETE", fmt.Sprintf("/projects/%s/users/%s", projectID, userID), nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("delete project user: %w", err)
+	}
+	var result DeletedProjectUserResponse
+	err = json.Unmarshal(respBody, &result)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal json: %w", err)
+	}
+	return &result, nil
+}
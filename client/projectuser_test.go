@@ -0,0 +1,174 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestListProjectUsers(t *testing.T) {
+	// Test data
+	projectID := "proj_123"
+	projectUsers := []ProjectUser{
+		{ID: "user_123", Object: "organization.project.user", Name: "First", Email: "first@example.com", Role: ProjectRoleOwner, AddedAt: 1711471533},
+		{ID: "user_456", Object: "organization.project.user", Name: "Second", Email: "second@example.com", Role: ProjectRoleMember, AddedAt: 1711471533},
+	}
+	responseBody, _ := json.Marshal(ListProjectUserResponse{Object: "list", Data: projectUsers, FirstID: "user_123", LastID: "user_456", HasMore: false})
+
+	// Create mock HTTP client
+	mockClient := &MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			if req.Method != "GET" {
+				t.Errorf("Expected method to be GET, got %s", req.Method)
+			}
+			if req.URL.Path != "/v1/organization/projects/"+projectID+"/users" {
+				t.Errorf("Expected path to be /v1/organization/projects/%s/users, got %s", projectID, req.URL.Path)
+			}
+			return &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(strings.NewReader(string(responseBody))),
+			}, nil
+		},
+	}
+
+	// Create client
+	client := &Client{
+		APIKey:     "test-api-key",
+		HTTPClient: mockClient,
+		BaseURL:    "https://api.openai.com/v1/organization",
+	}
+
+	// Test ListProjectUsers
+	result, err := client.ListProjectUsers(context.Background(), projectID)
+
+	// Verify result
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(*result, projectUsers) {
+		t.Errorf("Expected project users to be %+v, got %+v", projectUsers, *result)
+	}
+}
+
+func TestProjectUserRequests(t *testing.T) {
+	responseBody := `{"object":"organization.project.user","id":"user_123","name":"First","email":"first@example.com","role":"member","added_at":1711471533}`
+	tests := []struct {
+		name           string
+		expectedMethod string
+		expectedPath   string
+		expectedBody   string
+		call           func(c *Client) (*ProjectUser, error)
+	}{
+		{
+			name:           "AddProjectUser",
+			expectedMethod: "POST",
+			expectedPath:   "/v1/organization/projects/proj_123/users",
+			expectedBody:   `{"role":"member","user_id":"user_123"}`,
+			call: func(c *Client) (*ProjectUser, error) {
+				return c.AddProjectUser(context.Background(), "proj_123", "user_123", ProjectRoleMember)
+			},
+		},
+		{
+			name:           "GetProjectUser",
+			expectedMethod: "GET",
+			expectedPath:   "/v1/organization/projects/proj_123/users/user_123",
+			call: func(c *Client) (*ProjectUser, error) {
+				return c.GetProjectUser(context.Background(), "proj_123", "user_123")
+			},
+		},
+		{
+			name:           "ModifyProjectUserRole",
+			expectedMethod: "POST",
+			expectedPath:   "/v1/organization/projects/proj_123/users/user_123",
+			expectedBody:   `{"role":"member"}`,
+			call: func(c *Client) (*ProjectUser, error) {
+				return c.ModifyProjectUserRole(context.Background(), "proj_123", "user_123", ProjectRoleMember)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &Client{
+				APIKey: "test-api-key",
+				HTTPClient: &MockHTTPClient{
+					DoFunc: func(req *http.Request) (*http.Response, error) {
+						if req.Method != tt.expectedMethod {
+							t.Errorf("Expected method to be %s, got %s", tt.expectedMethod, req.Method)
+						}
+						if req.URL.Path != tt.expectedPath {
+							t.Errorf("Expected path to be %s, got %s", tt.expectedPath, req.URL.Path)
+						}
+						if tt.expectedBody != "" {
+							body, _ := io.ReadAll(req.Body)
+							if string(body) != tt.expectedBody {
+								t.Errorf("Expected request body to be %s, got %s", tt.expectedBody, string(body))
+							}
+						}
+						return &http.Response{
+							StatusCode: 200,
+							Body:       io.NopCloser(strings.NewReader(responseBody)),
+						}, nil
+					},
+				},
+				BaseURL: "https://api.openai.com/v1/organization",
+			}
+
+			user, err := tt.call(client)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if user.ID != "user_123" || user.Role != ProjectRoleMember {
+				t.Errorf("Expected member user_123, got %+v", user)
+			}
+		})
+	}
+}
+
+func TestDeleteProjectUser(t *testing.T) {
+	// Test data
+	expectedResponse := DeletedProjectUserResponse{
+		Object:  "organization.project.user.deleted",
+		ID:      "user_123",
+		Deleted: true,
+	}
+	responseBody, _ := json.Marshal(expectedResponse)
+
+	// Create mock HTTP client
+	mockClient := &MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			if req.Method != "DELETE" {
+				t.Errorf("Expected method to be DELETE, got %s", req.Method)
+			}
+			if req.URL.Path != "/v1/organization/projects/proj_123/users/user_123" {
+				t.Errorf("Expected path to be /v1/organization/projects/proj_123/users/user_123, got %s", req.URL.Path)
+			}
+			return &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(strings.NewReader(string(responseBody))),
+			}, nil
+		},
+	}
+
+	// Create client
+	client := &Client{
+		APIKey:     "test-api-key",
+		HTTPClient: mockClient,
+		BaseURL:    "https://api.openai.com/v1/organization",
+	}
+
+	// Test DeleteProjectUser
+	result, err := client.DeleteProjectUser(context.Background(), "proj_123", "user_123")
+
+	// Verify result
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(*result, expectedResponse) {
+		t.Errorf("Expected result to be %+v, got %+v", expectedResponse, *result)
+	}
+}
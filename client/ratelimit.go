@@ -0,0 +1,97 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"net/url"
+	"strconv"
+)
+
+// ProjectRateLimit represents a per-model rate limit of an OpenAI project.
+type ProjectRateLimit struct {
+	ID                          string `json:"id"`
+	Object                      string `json:"object"`
+	Model                       string `json:"model"`
+	MaxRequestsPer1Minute       int64  `json:"max_requests_per_1_minute"`
+	MaxTokensPer1Minute         int64  `json:"max_tokens_per_1_minute"`
+	MaxImagesPer1Minute         int64  `json:"max_images_per_1_minute,omitempty"`
+	MaxAudioMegabytesPer1Minute int64  `json:"max_audio_megabytes_per_1_minute,omitempty"`
+	MaxRequestsPer1Day          int64  `json:"max_requests_per_1_day,omitempty"`
+	Batch1DayMaxInputTokens     int64  `json:"batch_1_day_max_input_tokens,omitempty"`
+}
+
+// ModifyProjectRateLimitRequest holds the limits to change. Nil fields are left unchanged.
+type ModifyProjectRateLimitRequest struct {
+	MaxRequestsPer1Minute       *int64 `json:"max_requests_per_1_minute,omitempty"`
+	MaxTokensPer1Minute         *int64 `json:"max_tokens_per_1_minute,omitempty"`
+	MaxImagesPer1Minute         *int64 `json:"max_images_per_1_minute,omitempty"`
+	MaxAudioMegabytesPer1Minute *int64 `json:"max_audio_megabytes_per_1_minute,omitempty"`
+	MaxRequestsPer1Day          *int64 `json:"max_requests_per_1_day,omitempty"`
+	Batch1DayMaxInputTokens     *int64 `json:"batch_1_day_max_input_tokens,omitempty"`
+}
+
+// ListProjectRateLimitResponse represents the response from the list project rate limits API.
+type ListProjectRateLimitResponse struct {
+	Object  string             `json:"object"`
+	Data    []ProjectRateLimit `json:"data"`
+	FirstID string             `json:"first_id"`
+	LastID  string             `json:"last_id"`
+	HasMore bool               `json:"has_more"`
+}
+
+// ListProjectRateLimits retrieves all per-model rate limits for a project.
+func (c *Client) ListProjectRateLimits(ctx context.Context, projectID string) (*[]ProjectRateLimit, error) {
+	return collect(c.ProjectRateLimits(ctx, projectID))
+}
+
+// ProjectRateLimits returns an iterator over all per-model rate limits for a project.
+func (c *Client) ProjectRateLimits(ctx context.Context, projectID string) iter.Seq2[ProjectRateLimit, error] {
+	const pageSize = 100
+	return paginate(func(after string) ([]ProjectRateLimit, string, bool, error) {
+		resp, err := c.listProjectRateLimits(ctx, projectID, after, pageSize)
+		if err != nil {
+			return nil, "", false, fmt.Errorf("get project rate limit list: %w", err)
+		}
+		return resp.Data, resp.LastID, resp.HasMore, nil
+	})
+}
+
+// listProjectRateLimits retrieves a page of project rate limits with pagination options.
+func (c *Client) listProjectRateLimits(ctx context.Context, projectID string, after string, limit int) (*ListProjectRateLimitResponse, error) {
+	query := url.Values{}
+	if after != "" {
+		query.Set("after", after)
+	}
+	if limit > 0 {
+		query.Set("limit", strconv.Itoa(limit))
+	}
+
+	path := fmt.Sprintf("/projects/%s/rate_limits", projectID)
+	respBody, err := c.doRequest(ctx, "GET", path, query, nil)
+	if err != nil {
+		return nil, fmt.Errorf("execute request: %w", err)
+	}
+	var result ListProjectRateLimitResponse
+	err = json.Unmarshal(respBody, &result)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal json: %w", err)
+	}
+	return &result, nil
+}
+
+// ModifyProjectRateLimit updates a per-model rate limit of a project.
+func (c *Client) ModifyProjectRateLimit(ctx context.Context, projectID string, rateLimitID string, limits ModifyProjectRateLimitRequest) (*ProjectRateLimit, error) {
+	path := fmt.Sprintf("/projects/%s/rate_limits/%s", projectID, rateLimitID)
+	respBody, err := c.doRequest(WithIdempotent(ctx), "POST", path, nil, limits)
+	if err != nil {
+		return nil, fmt.Errorf("modify project rate limit: %w", err)
+	}
+	var rateLimit ProjectRateLimit
+	err = json.Unmarshal(respBody, &rateLimit)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal json: %w", err)
+	}
+	return &rateLimit, nil
+}
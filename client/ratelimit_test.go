@@ -0,0 +1,152 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestListProjectRateLimits_Pagination(t *testing.T) {
+	// Test data
+	projectID := "proj_123"
+	firstPageLimits := []ProjectRateLimit{
+		{
+			ID:                    "rl-gpt-4o",
+			Object:                "project.rate_limit",
+			Model:                 "gpt-4o",
+			MaxRequestsPer1Minute: 500,
+			MaxTokensPer1Minute:   30000,
+		},
+	}
+	firstPageBody, _ := json.Marshal(ListProjectRateLimitResponse{
+		Object:  "list",
+		Data:    firstPageLimits,
+		FirstID: "rl-gpt-4o",
+		LastID:  "rl-gpt-4o",
+		HasMore: true,
+	})
+	secondPageLimits := []ProjectRateLimit{
+		{
+			ID:                    "rl-dall-e-3",
+			Object:                "project.rate_limit",
+			Model:                 "dall-e-3",
+			MaxRequestsPer1Minute: 50,
+			MaxTokensPer1Minute:   0,
+			MaxImagesPer1Minute:   5,
+		},
+	}
+	secondPageBody, _ := json.Marshal(ListProjectRateLimitResponse{
+		Object:  "list",
+		Data:    secondPageLimits,
+		FirstID: "rl-dall-e-3",
+		LastID:  "rl-dall-e-3",
+		HasMore: false,
+	})
+
+	// Create mock HTTP client
+	callCount := 0
+	mockClient := &MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			callCount++
+			if req.URL.Path != "/v1/organization/projects/"+projectID+"/rate_limits" {
+				t.Errorf("Expected path to be /v1/organization/projects/%s/rate_limits, got %s", projectID, req.URL.Path)
+			}
+			body := firstPageBody
+			if callCount == 2 {
+				if req.URL.Query().Get("after") != "rl-gpt-4o" {
+					t.Errorf("Expected 'after' query parameter to be 'rl-gpt-4o' on second call, got '%s'", req.URL.Query().Get("after"))
+				}
+				body = secondPageBody
+			}
+			return &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(strings.NewReader(string(body))),
+			}, nil
+		},
+	}
+
+	// Create client
+	client := &Client{
+		APIKey:     "test-api-key",
+		HTTPClient: mockClient,
+		BaseURL:    "https://api.openai.com/v1/organization",
+	}
+
+	// Test ListProjectRateLimits
+	rateLimits, err := client.ListProjectRateLimits(context.Background(), projectID)
+
+	// Verify result
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	expectedLimits := append(firstPageLimits, secondPageLimits...)
+	if !reflect.DeepEqual(*rateLimits, expectedLimits) {
+		t.Errorf("Expected rate limits to be %+v, got %+v", expectedLimits, *rateLimits)
+	}
+	if callCount != 2 {
+		t.Errorf("Expected 2 API calls, got %d", callCount)
+	}
+}
+
+func TestModifyProjectRateLimit(t *testing.T) {
+	// Test data
+	projectID := "proj_123"
+	rateLimitID := "rl-gpt-4o"
+	maxRequests := int64(100)
+	expectedRateLimit := ProjectRateLimit{
+		ID:                    rateLimitID,
+		Object:                "project.rate_limit",
+		Model:                 "gpt-4o",
+		MaxRequestsPer1Minute: maxRequests,
+		MaxTokensPer1Minute:   30000,
+	}
+	responseBody, _ := json.Marshal(expectedRateLimit)
+
+	// Create mock HTTP client
+	mockClient := &MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			if req.Method != "POST" {
+				t.Errorf("Expected method to be POST, got %s", req.Method)
+			}
+			expectedURL := "https://api.openai.com/v1/organization/projects/" + projectID + "/rate_limits/" + rateLimitID
+			if req.URL.String() != expectedURL {
+				t.Errorf("Expected URL to be %s, got %s", expectedURL, req.URL.String())
+			}
+
+			// Only the fields being changed should be sent
+			body, _ := io.ReadAll(req.Body)
+			if string(body) != `{"max_requests_per_1_minute":100}` {
+				t.Errorf("Expected request body to be %s, got %s", `{"max_requests_per_1_minute":100}`, string(body))
+			}
+
+			return &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(strings.NewReader(string(responseBody))),
+			}, nil
+		},
+	}
+
+	// Create client
+	client := &Client{
+		APIKey:     "test-api-key",
+		HTTPClient: mockClient,
+		BaseURL:    "https://api.openai.com/v1/organization",
+	}
+
+	// Test ModifyProjectRateLimit
+	rateLimit, err := client.ModifyProjectRateLimit(context.Background(), projectID, rateLimitID, ModifyProjectRateLimitRequest{
+		MaxRequestsPer1Minute: &maxRequests,
+	})
+
+	// Verify result
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(*rateLimit, expectedRateLimit) {
+		t.Errorf("Expected rate limit to be %+v, got %+v", expectedRateLimit, *rateLimit)
+	}
+}
@@ -0,0 +1,245 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// RecorderMode selects whether a Recorder talks to the real API or replays fixtures.
+type RecorderMode int
+
+const (
+	ModeReplay RecorderMode = iota // Serve responses from the fixture file
+	ModeRecord                     // Forward requests and record the interactions
+)
+
+// Redacted replaces secrets in recorded fixtures.
+const Redacted = "REDACTED"
+
+// scrubbedResponseHeaders identify the organization or the individual request in API responses
+// and are redacted before anything is written.
+var scrubbedResponseHeaders = []string{
+	"Openai-Organization",
+	"Openai-Project",
+	"X-Request-Id",
+	"Set-Cookie",
+	"Cf-Ray",
+}
+
+// ErrNoInteraction is returned in replay mode when no recorded interaction matches a request.
+var ErrNoInteraction = errors.New("no recorded interaction")
+
+// Interaction is a recorded request and its response.
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest is the request part of an Interaction.
+type RecordedRequest struct {
+	Method string          `json:"method"`
+	URL    string          `json:"url"`
+	Header http.Header     `json:"header,omitempty"`
+	Body   json.RawMessage `json:"body,omitempty"`
+}
+
+// RecordedResponse is the response part of an Interaction.
+type RecordedResponse struct {
+	StatusCode int             `json:"status_code"`
+	Header     http.Header     `json:"header,omitempty"`
+	Body       json.RawMessage `json:"body,omitempty"`
+}
+
+// Recorder is an HTTPClient that records interactions with the admin API to a fixture file
+// or replays them from it. The Authorization header, response headers that identify the organization
+// or request, and every "value" field, which carries API keys, are redacted before anything is written.
+type Recorder struct {
+	mode         RecorderMode
+	path         string
+	client       HTTPClient // Client used to reach the real API in record mode
+	mu           sync.Mutex
+	interactions []Interaction
+	used         []bool
+}
+
+// NewRecorder creates a recorder for the fixture at path. In replay mode the fixture is loaded
+// immediately and client may be nil; in record mode requests are sent through client and
+// written to path by Save.
+func NewRecorder(path string, mode RecorderMode, client HTTPClient) (*Recorder, error) {
+	r := &Recorder{
+		mode:   mode,
+		path:   path,
+		client: client,
+	}
+	if mode == ModeRecord {
+		if client == nil {
+			return nil, fmt.Errorf("record %s: http client is required", path)
+		}
+		return r, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read fixture: %w", err)
+	}
+	if err := json.Unmarshal(data, &r.interactions); err != nil {
+		return nil, fmt.Errorf("unmarshal fixture: %w", err)
+	}
+	r.used = make([]bool, len(r.interactions))
+	return r, nil
+}
+
+// Do records or replays a single request.
+func (r *Recorder) Do(req *http.Request) (*http.Response, error) {
+	var reqBody []byte
+	if req.Body != nil {
+		var err error
+		reqBody, err = io.ReadAll(req.Body)
+		if err != nil {
+			return nil, fmt.Errorf("read request body: %w", err)
+		}
+		req.Body = io.NopCloser(bytes.NewReader(reqBody))
+	}
+	if r.mode == ModeRecord {
+		return r.record(req, reqBody)
+	}
+	return r.replay(req)
+}
+
+// Save writes the recorded interactions to the fixture file.
+func (r *Recorder) Save() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.mode != ModeRecord {
+		return nil
+	}
+	data, err := json.MarshalIndent(r.interactions, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal fixture: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return fmt.Errorf("create fixture directory: %w", err)
+	}
+	if err := os.WriteFile(r.path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("write fixture: %w", err)
+	}
+	return nil
+}
+
+// record forwards the request to the real API and stores the redacted interaction.
+func (r *Recorder) record(req *http.Request, reqBody []byte) (*http.Response, error) {
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := io.ReadAll(resp.Body)
+	if closeErr := resp.Body.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read response body: %w", err)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	header := req.Header.Clone()
+	if header.Get("Authorization") != "" {
+		header.Set("Authorization", Redacted)
+	}
+	respHeader := resp.Header.Clone()
+	for _, name := range scrubbedResponseHeaders {
+		if respHeader.Get(name) != "" {
+			respHeader.Set(name, Redacted)
+		}
+	}
+	interaction := Interaction{
+		Request: RecordedRequest{
+			Method: req.Method,
+			URL:    req.URL.String(),
+			Header: header,
+			Body:   redactJSON(reqBody),
+		},
+		Response: RecordedResponse{
+			StatusCode: resp.StatusCode,
+			Header:     respHeader,
+			Body:       redactJSON(respBody),
+		},
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.interactions = append(r.interactions, interaction)
+	return resp, nil
+}
+
+// replay returns the first unused interaction with the same method and URL.
+func (r *Recorder) replay(req *http.Request) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, interaction := range r.interactions {
+		if r.used[i] || interaction.Request.Method != req.Method || interaction.Request.URL != req.URL.String() {
+			continue
+		}
+		r.used[i] = true
+		return &http.Response{
+			StatusCode: interaction.Response.StatusCode,
+			Header:     interaction.Response.Header.Clone(),
+			Body:       io.NopCloser(bytes.NewReader(rawBody(interaction.Response.Body))),
+			Request:    req,
+		}, nil
+	}
+	return nil, fmt.Errorf("%w for %s %s", ErrNoInteraction, req.Method, req.URL)
+}
+
+// redactJSON replaces every "value" field in a JSON body. Bodies that are not JSON are
+// stored as JSON strings.
+func redactJSON(body []byte) json.RawMessage {
+	if len(body) == 0 {
+		return nil
+	}
+	var v any
+	if err := json.Unmarshal(body, &v); err != nil {
+		quoted, _ := json.Marshal(string(body))
+		return quoted
+	}
+	redacted, err := json.Marshal(redactValue(v))
+	if err != nil {
+		return nil
+	}
+	return redacted
+}
+
+// rawBody reverses the string encoding redactJSON applies to bodies that are not JSON.
+func rawBody(body json.RawMessage) []byte {
+	var text string
+	if len(body) > 0 && body[0] == '"' && json.Unmarshal(body, &text) == nil {
+		return []byte(text)
+	}
+	return body
+}
+
+// redactValue replaces string "value" fields in a decoded JSON document.
+func redactValue(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for key, child := range v {
+			if key == "value" {
+				if _, ok := child.(string); ok {
+					v[key] = Redacted
+					continue
+				}
+			}
+			v[key] = redactValue(child)
+		}
+	case []any:
+		for i, child := range v {
+			v[i] = redactValue(child)
+		}
+	}
+	return v
+}
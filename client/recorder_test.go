@@ -0,0 +1,126 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRecorder_RecordAndReplay(t *testing.T) {
+	// Test data
+	path := filepath.Join(t.TempDir(), "fixture.json")
+	responseBody := `{"id":"svc_acct_abc","api_key":{"id":"key_abc","value":"sk-svcacct-secret"}}`
+
+	// Create mock HTTP client standing in for the real API
+	mockClient := &MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: 200,
+				Header: http.Header{
+					"Content-Type":        []string{"application/json"},
+					"Openai-Organization": []string{"org-secret"},
+					"X-Request-Id":        []string{"req_secret"},
+				},
+				Body: io.NopCloser(strings.NewReader(responseBody)),
+			}, nil
+		},
+	}
+
+	// Record an interaction
+	recorder, err := NewRecorder(path, ModeRecord, mockClient)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	client := &Client{APIKey: "sk-admin-secret", HTTPClient: recorder, BaseURL: "https://api.openai.com/v1/organization"}
+	sa, err := client.CreateServiceAccount(context.Background(), "proj_abc", "user@example.com")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if sa.APIKey.Value != "sk-svcacct-secret" {
+		t.Errorf("Expected the caller to receive the real key while recording, got '%s'", sa.APIKey.Value)
+	}
+	if err := recorder.Save(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// Verify secrets are redacted in the fixture
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for _, secret := range []string{"sk-admin-secret", "sk-svcacct-secret", "org-secret", "req_secret"} {
+		if strings.Contains(string(data), secret) {
+			t.Errorf("Expected fixture not to contain '%s', got %s", secret, data)
+		}
+	}
+
+	// Replay the interaction
+	replayer, err := NewRecorder(path, ModeReplay, nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	client.HTTPClient = replayer
+	sa, err = client.CreateServiceAccount(context.Background(), "proj_abc", "user@example.com")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if sa.ID != "svc_acct_abc" || sa.APIKey.ID != "key_abc" || sa.APIKey.Value != Redacted {
+		t.Errorf("Expected replayed service account with redacted key, got %+v", sa)
+	}
+
+	// Each interaction is replayed only once
+	_, err = client.CreateServiceAccount(context.Background(), "proj_abc", "user@example.com")
+	if !errors.Is(err, ErrNoInteraction) {
+		t.Errorf("Expected error to be %v, got %v", ErrNoInteraction, err)
+	}
+}
+
+func TestRecorder_ReplayNonJSONBody(t *testing.T) {
+	// Test data
+	path := filepath.Join(t.TempDir(), "fixture.json")
+	fixture := `[{"request":{"method":"GET","url":"https://api.openai.com/v1/organization/test-path"},` +
+		`"response":{"status_code":502,"body":"Bad Gateway"}}]`
+	if err := os.WriteFile(path, []byte(fixture), 0o644); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// Replay the interaction
+	recorder, err := NewRecorder(path, ModeReplay, nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	client := &Client{APIKey: "test-api-key", HTTPClient: recorder, BaseURL: "https://api.openai.com/v1/organization"}
+	_, err = client.doRequest(context.Background(), "GET", "/test-path", nil, nil)
+
+	// Verify result
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != 502 || apiErr.Message != "Bad Gateway" {
+		t.Errorf("Expected *APIError with status 502 and message 'Bad Gateway', got %v", err)
+	}
+}
+
+func TestRedactJSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		expected string
+	}{
+		{name: "Empty", body: "", expected: ""},
+		{name: "Nested value", body: `{"data":[{"api_key":{"value":"sk-1"}}]}`, expected: `{"data":[{"api_key":{"value":"REDACTED"}}]}`},
+		{name: "Non-string value", body: `{"value":3}`, expected: `{"value":3}`},
+		{name: "Not JSON", body: "Bad Gateway", expected: `"Bad Gateway"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := string(redactJSON([]byte(tt.body))); got != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, got)
+			}
+		})
+	}
+}
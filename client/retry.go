@@ -0,0 +1,167 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy configures how failed requests are retried.
+type RetryPolicy struct {
+	MaxRetries int           // Maximum number of retries after the first attempt
+	BaseDelay  time.Duration // Backoff delay before the first retry
+	MaxDelay   time.Duration // Upper bound for a single backoff delay; a server's Retry-After is honored beyond it
+}
+
+// DefaultRetryPolicy returns the retry policy used by NewClient.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxRetries: 3,
+		BaseDelay:  500 * time.Millisecond,
+		MaxDelay:   10 * time.Second,
+	}
+}
+
+// RetryError reports a request that was attempted more than once.
+type RetryError struct {
+	Attempts int    // Total number of attempts made
+	Reason   string // Why retrying stopped
+	Err      error  // Error from the final attempt
+}
+
+func (e *RetryError) Error() string {
+	return fmt.Sprintf("%s after %d attempts: %v", e.Reason, e.Attempts, e.Err)
+}
+
+func (e *RetryError) Unwrap() error {
+	return e.Err
+}
+
+// Reasons reported by RetryError.
+const (
+	RetryReasonExhausted = "retries exhausted"
+	RetryReasonDeadline  = "context deadline too short to retry"
+	RetryReasonCanceled  = "context canceled while waiting to retry"
+	RetryReasonPermanent = "non-retryable error"
+)
+
+// transportError marks a failure to exchange a request with the API, as opposed to a local
+// failure to build the request.
+type transportError struct {
+	err error
+}
+
+func (e *transportError) Error() string {
+	return e.err.Error()
+}
+
+func (e *transportError) Unwrap() error {
+	return e.err
+}
+
+type idempotentKey struct{}
+
+// WithIdempotent marks requests made with the returned context as safe to retry,
+// allowing POST requests to be retried like GET requests.
+func WithIdempotent(ctx context.Context) context.Context {
+	return context.WithValue(ctx, idempotentKey{}, true)
+}
+
+// isIdempotent reports whether a request with the given method may be retried.
+func isIdempotent(ctx context.Context, method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	marked, _ := ctx.Value(idempotentKey{}).(bool)
+	return marked
+}
+
+// isRetryable reports whether a failed attempt is worth retrying: transport failures and
+// responses with a transient status code.
+func isRetryable(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	if errors.Is(err, ErrQuotaExceeded) {
+		return false
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		switch apiErr.StatusCode {
+		case http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusBadGateway,
+			http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			return true
+		}
+		return false
+	}
+	var transportErr *transportError
+	if errors.As(err, &transportErr) {
+		return true
+	}
+	// A malformed URL is reported as a *url.Error, which also implements net.Error.
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) && urlErr.Op == "parse" {
+		return false
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+// backoff returns the jittered delay before the given retry (starting at 1).
+func (p RetryPolicy) backoff(retry int) time.Duration {
+	if p.BaseDelay <= 0 {
+		return 0
+	}
+	delay := p.BaseDelay
+	for i := 1; i < retry && (p.MaxDelay <= 0 || delay < p.MaxDelay); i++ {
+		delay *= 2
+	}
+	if p.MaxDelay > 0 && delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+	// Equal jitter: keep half of the delay and randomize the rest.
+	half := delay / 2
+	return half + rand.N(half+1)
+}
+
+// parseRetryAfter parses a Retry-After header given in seconds or as an HTTP date.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		if d := t.Sub(now); d > 0 {
+			return d, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
+
+// sleep waits for the delay, returning early with false if the context is done.
+func sleep(ctx context.Context, delay time.Duration) bool {
+	if delay <= 0 {
+		return ctx.Err() == nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
@@ -0,0 +1,293 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newRetryTestClient(doFunc func(req *http.Request) (*http.Response, error)) *Client {
+	return &Client{
+		APIKey:     "test-api-key",
+		HTTPClient: &MockHTTPClient{DoFunc: doFunc},
+		BaseURL:    "https://api.openai.com/v1/organization",
+		Retry: RetryPolicy{
+			MaxRetries: 2,
+			BaseDelay:  time.Millisecond,
+			MaxDelay:   5 * time.Millisecond,
+		},
+	}
+}
+
+func TestNewClient_DefaultRetryPolicy(t *testing.T) {
+	client := NewClient("test-api-key", &MockHTTPClient{})
+	if client.Retry != DefaultRetryPolicy() {
+		t.Errorf("Expected Retry to be %+v, got %+v", DefaultRetryPolicy(), client.Retry)
+	}
+}
+
+func TestDoRequest_RetrySuccess(t *testing.T) {
+	callCount := 0
+	client := newRetryTestClient(func(req *http.Request) (*http.Response, error) {
+		callCount++
+		if callCount < 3 {
+			return &http.Response{
+				StatusCode: http.StatusBadGateway,
+				Body:       io.NopCloser(strings.NewReader("Bad Gateway")),
+			}, nil
+		}
+		return &http.Response{
+			StatusCode: 200,
+			Body:       io.NopCloser(strings.NewReader(`{"ok":true}`)),
+		}, nil
+	})
+
+	result, err := client.doRequest(context.Background(), "GET", "/test-path", nil, nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if string(result) != `{"ok":true}` {
+		t.Errorf("Expected response body to be %s, got %s", `{"ok":true}`, string(result))
+	}
+	if callCount != 3 {
+		t.Errorf("Expected 3 API calls, got %d", callCount)
+	}
+}
+
+func TestDoRequest_RetryExhausted(t *testing.T) {
+	callCount := 0
+	client := newRetryTestClient(func(req *http.Request) (*http.Response, error) {
+		callCount++
+		return &http.Response{
+			StatusCode: http.StatusServiceUnavailable,
+			Body:       io.NopCloser(strings.NewReader("Service Unavailable")),
+		}, nil
+	})
+
+	_, err := client.doRequest(context.Background(), "GET", "/test-path", nil, nil)
+
+	var retryErr *RetryError
+	if !errors.As(err, &retryErr) {
+		t.Fatalf("Expected error to be of type *RetryError, got %T", err)
+	}
+	if retryErr.Attempts != 3 {
+		t.Errorf("Expected 3 attempts, got %d", retryErr.Attempts)
+	}
+	if retryErr.Reason != RetryReasonExhausted {
+		t.Errorf("Expected reason to be '%s', got '%s'", RetryReasonExhausted, retryErr.Reason)
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("Expected wrapped *APIError with status code 503, got %v", err)
+	}
+	if callCount != 3 {
+		t.Errorf("Expected 3 API calls, got %d", callCount)
+	}
+}
+
+func TestDoRequest_NoRetryOnClientError(t *testing.T) {
+	callCount := 0
+	client := newRetryTestClient(func(req *http.Request) (*http.Response, error) {
+		callCount++
+		return &http.Response{
+			StatusCode: http.StatusBadRequest,
+			Body:       io.NopCloser(strings.NewReader("Bad Request")),
+		}, nil
+	})
+
+	_, err := client.doRequest(context.Background(), "GET", "/test-path", nil, nil)
+
+	var retryErr *RetryError
+	if errors.As(err, &retryErr) {
+		t.Errorf("Expected no *RetryError for a single attempt, got %v", err)
+	}
+	if callCount != 1 {
+		t.Errorf("Expected 1 API call, got %d", callCount)
+	}
+}
+
+func TestDoRequest_NoRetryOnPost(t *testing.T) {
+	callCount := 0
+	client := newRetryTestClient(func(req *http.Request) (*http.Response, error) {
+		callCount++
+		return nil, errors.New("connection reset")
+	})
+
+	_, err := client.doRequest(context.Background(), "POST", "/test-path", nil, map[string]string{"name": "test"})
+	if err == nil {
+		t.Fatal("Expected error, got nil")
+	}
+	if callCount != 1 {
+		t.Errorf("Expected 1 API call, got %d", callCount)
+	}
+}
+
+func TestDoRequest_RetryIdempotentPost(t *testing.T) {
+	callCount := 0
+	client := newRetryTestClient(func(req *http.Request) (*http.Response, error) {
+		callCount++
+		body, _ := io.ReadAll(req.Body)
+		if string(body) != `{"name":"test"}` {
+			t.Errorf("Expected request body to be resent on every attempt, got '%s'", string(body))
+		}
+		if callCount == 1 {
+			return nil, errors.New("connection reset")
+		}
+		return &http.Response{
+			StatusCode: 200,
+			Body:       io.NopCloser(strings.NewReader(`{}`)),
+		}, nil
+	})
+
+	ctx := WithIdempotent(context.Background())
+	if _, err := client.doRequest(ctx, "POST", "/test-path", nil, map[string]string{"name": "test"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if callCount != 2 {
+		t.Errorf("Expected 2 API calls, got %d", callCount)
+	}
+}
+
+func TestDoRequest_RetryAfterExceedsDeadline(t *testing.T) {
+	callCount := 0
+	client := newRetryTestClient(func(req *http.Request) (*http.Response, error) {
+		callCount++
+		return &http.Response{
+			StatusCode: http.StatusTooManyRequests,
+			Header:     http.Header{"Retry-After": []string{"30"}},
+			Body:       io.NopCloser(strings.NewReader("Too Many Requests")),
+		}, nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	_, err := client.doRequest(ctx, "GET", "/test-path", nil, nil)
+
+	var retryErr *RetryError
+	if !errors.As(err, &retryErr) {
+		t.Fatalf("Expected error to be of type *RetryError, got %T", err)
+	}
+	if retryErr.Reason != RetryReasonDeadline {
+		t.Errorf("Expected reason to be '%s', got '%s'", RetryReasonDeadline, retryErr.Reason)
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.RetryAfter != 30*time.Second {
+		t.Errorf("Expected wrapped *APIError with RetryAfter 30s, got %v", err)
+	}
+	if callCount != 1 {
+		t.Errorf("Expected 1 API call, got %d", callCount)
+	}
+}
+
+func TestDoRequest_RetryAfterExceedsMaxDelay(t *testing.T) {
+	callCount := 0
+	client := newRetryTestClient(func(req *http.Request) (*http.Response, error) {
+		callCount++
+		if callCount == 1 {
+			return &http.Response{
+				StatusCode: http.StatusTooManyRequests,
+				Header:     http.Header{"Retry-After": []string{"1"}},
+				Body:       io.NopCloser(strings.NewReader("Too Many Requests")),
+			}, nil
+		}
+		return &http.Response{
+			StatusCode: 200,
+			Body:       io.NopCloser(strings.NewReader(`{"ok":true}`)),
+		}, nil
+	})
+
+	// Without a deadline the request waits out a Retry-After longer than MaxDelay
+	start := time.Now()
+	_, err := client.doRequest(context.Background(), "DELETE", "/test-path", nil, nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if callCount != 2 {
+		t.Errorf("Expected 2 API calls, got %d", callCount)
+	}
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Errorf("Expected the request to wait out Retry-After, took %v", elapsed)
+	}
+}
+
+func TestDoRequest_NoRetryOnLocalError(t *testing.T) {
+	callCount := 0
+	client := newRetryTestClient(func(req *http.Request) (*http.Response, error) {
+		callCount++
+		return nil, errors.New("unexpected request")
+	})
+	client.BaseURL = "://invalid"
+
+	_, err := client.doRequest(context.Background(), "GET", "/test-path", nil, nil)
+
+	var retryErr *RetryError
+	if err == nil || errors.As(err, &retryErr) {
+		t.Errorf("Expected a single failed attempt, got %v", err)
+	}
+	if callCount != 0 {
+		t.Errorf("Expected 0 API calls, got %d", callCount)
+	}
+}
+
+func TestIsRetryable(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{"Transient status", &APIError{StatusCode: http.StatusServiceUnavailable}, true},
+		{"Client error", &APIError{StatusCode: http.StatusBadRequest}, false},
+		{"Transport failure", fmt.Errorf("execute http request: %w", &transportError{errors.New("connection reset")}), true},
+		{"Network error", &net.OpError{Op: "dial", Err: errors.New("connection refused")}, true},
+		{"Local failure", fmt.Errorf("marshal json: %w", errors.New("unsupported type")), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isRetryable(context.Background(), tt.err); got != tt.expected {
+				t.Errorf("isRetryable(%v) = %v, want %v", tt.err, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		value    string
+		expected time.Duration
+		ok       bool
+	}{
+		{name: "Empty", value: "", expected: 0, ok: false},
+		{name: "Seconds", value: "5", expected: 5 * time.Second, ok: true},
+		{name: "Negative seconds", value: "-1", expected: 0, ok: false},
+		{name: "HTTP date", value: now.Add(10 * time.Second).Format(http.TimeFormat), expected: 10 * time.Second, ok: true},
+		{name: "Past HTTP date", value: now.Add(-10 * time.Second).Format(http.TimeFormat), expected: 0, ok: true},
+		{name: "Invalid", value: "soon", expected: 0, ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, ok := parseRetryAfter(tt.value, now)
+			if d != tt.expected || ok != tt.ok {
+				t.Errorf("Expected (%v, %v), got (%v, %v)", tt.expected, tt.ok, d, ok)
+			}
+		})
+	}
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := RetryPolicy{BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}
+	for retry := 1; retry <= 6; retry++ {
+		delay := policy.backoff(retry)
+		if delay < 0 || delay > policy.MaxDelay {
+			t.Errorf("Expected delay for retry %d to be within [0, %v], got %v", retry, policy.MaxDelay, delay)
+		}
+	}
+}
@@ -4,24 +4,30 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"iter"
 	"net/url"
 	"strconv"
 )
 
 // ServiceAccount represents an OpenAI service account with its associated API key.
 type ServiceAccount struct {
-	ID        string `json:"id"`
-	Object    string `json:"object"`
-	Name      string `json:"name"`
-	Role      string `json:"role"`
-	CreatedAt int64  `json:"created_at"`
-	APIKey    struct {
-		Object    string `json:"object"`
-		Value     string `json:"value"`
-		Name      string `json:"name"`
-		CreatedAt int64  `json:"created_at"`
-		ID        string `json:"id"`
-	} `json:"api_key"`
+	ID        string               `json:"id"`
+	Object    string               `json:"object"`
+	Name      string               `json:"name"`
+	Role      string               `json:"role"`
+	CreatedAt int64                `json:"created_at"`
+	APIKey    ServiceAccountAPIKey `json:"api_key"`
+}
+
+// ServiceAccountAPIKey represents the API key of a service account. Value is only returned when
+// the service account is created; RedactedValue is filled in by GetServiceAccount when given the key ID.
+type ServiceAccountAPIKey struct {
+	Object        string `json:"object"`
+	Value         string `json:"value"`
+	RedactedValue string `json:"redacted_value,omitempty"`
+	Name          string `json:"name"`
+	CreatedAt     int64  `json:"created_at"`
+	ID            string `json:"id"`
 }
 
 // ListServiceAccountResponse represents the response from the list service accounts API.
@@ -43,37 +49,70 @@ type DeletedServiceAccountResponse struct {
 // CreateServiceAccount creates a new service account in the specified project.
 func (c *Client) CreateServiceAccount(ctx context.Context, projectID string, name string) (*ServiceAccount, error) {
 	body := map[string]string{"name": name}
-	respBody, err := c.doRequest(ctx, "POST", fmt.Sprintf("/projects/%s/service_accounts", projectID), nil, body)
+	path := fmt.Sprintf("/projects/%s/service_accounts", projectID)
+	respBody, err := c.doRequest(ctx, "POST", path, nil, body)
 	if err != nil {
 		return nil, fmt.Errorf("create service account: %w", err)
 	}
 	var sa ServiceAccount
-	err = json.Unmarshal(respBody, &sa)
+	err = c.decodeJSON(path, respBody, &sa)
 	if err != nil {
 		return nil, fmt.Errorf("unmarshal json: %w", err)
 	}
 	return &sa, nil
 }
 
+// GetServiceAccount retrieves a service account by ID. If keyID names its API key, such as the key ID
+// recorded when the key was issued, the key's metadata and redacted value are retrieved as well;
+// otherwise the account is returned without its key, which GetProjectAPIKey or ListProjectAPIKeys can resolve.
+func (c *Client) GetServiceAccount(ctx context.Context, projectID string, serviceAccountID string, keyID string) (*ServiceAccount, error) {
+	path := fmt.Sprintf("/projects/%s/service_accounts/%s", projectID, serviceAccountID)
+	respBody, err := c.doRequest(ctx, "GET", path, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("get service account: %w", err)
+	}
+	var sa ServiceAccount
+	err = c.decodeJSON(path, respBody, &sa, "api_key")
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal json: %w", err)
+	}
+	if keyID == "" {
+		return &sa, nil
+	}
+
+	// The service account endpoint does not include the key, so fetch it separately.
+	key, err := c.GetProjectAPIKey(ctx, projectID, keyID)
+	if err != nil {
+		return nil, fmt.Errorf("get service account api key: %w", err)
+	}
+	if key.Owner.ServiceAccount == nil || key.Owner.ServiceAccount.ID != serviceAccountID {
+		return nil, fmt.Errorf("api key %s does not belong to service account %s", keyID, serviceAccountID)
+	}
+	sa.APIKey = ServiceAccountAPIKey{
+		Object:        key.Object,
+		RedactedValue: key.RedactedValue,
+		Name:          key.Name,
+		CreatedAt:     key.CreatedAt,
+		ID:            key.ID,
+	}
+	return &sa, nil
+}
+
 // ListServiceAccounts retrieves all service accounts for a project.
 func (c *Client) ListServiceAccounts(ctx context.Context, projectID string) (*[]ServiceAccount, error) {
-	var allAccounts []ServiceAccount
-	var after string
-	const pageSize = 100
+	return collect(c.ServiceAccounts(ctx, projectID))
+}
 
-	for {
+// ServiceAccounts returns an iterator over all service accounts for a project.
+func (c *Client) ServiceAccounts(ctx context.Context, projectID string) iter.Seq2[ServiceAccount, error] {
+	const pageSize = 100
+	return paginate(func(after string) ([]ServiceAccount, string, bool, error) {
 		resp, err := c.listServiceAccounts(ctx, projectID, after, pageSize)
 		if err != nil {
-			return nil, fmt.Errorf("get service account list: %w", err)
-		}
-		allAccounts = append(allAccounts, resp.Data...)
-		if !resp.HasMore {
-			break
+			return nil, "", false, fmt.Errorf("get service account list: %w", err)
 		}
-		after = resp.LastID
-	}
-
-	return &allAccounts, nil
+		return resp.Data, resp.LastID, resp.HasMore, nil
+	})
 }
 
 // listServiceAccounts retrieves a page of service accounts with pagination options.
@@ -92,7 +131,7 @@ func (c *Client) listServiceAccounts(ctx context.Context, projectID string, afte
 		return nil, fmt.Errorf("execute request: %w", err)
 	}
 	var result ListServiceAccountResponse
-	err = json.Unmarshal(respBody, &result)
+	err = c.decodeJSON(path, respBody, &result, "data.api_key")
 	if err != nil {
 		return nil, fmt.Errorf("unmarshal json: %w", err)
 	}
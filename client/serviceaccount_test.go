@@ -20,13 +20,7 @@ func TestCreateServiceAccount(t *testing.T) {
 		Name:      serviceAccountName,
 		Role:      "owner",
 		CreatedAt: 1617123456,
-		APIKey: struct {
-			Object    string `json:"object"`
-			Value     string `json:"value"`
-			Name      string `json:"name"`
-			CreatedAt int64  `json:"created_at"`
-			ID        string `json:"id"`
-		}{
+		APIKey: ServiceAccountAPIKey{
 			Object:    "api_key",
 			Value:     "sk-test-key",
 			Name:      "test-key",
@@ -367,3 +361,104 @@ func TestDeleteServiceAccount(t *testing.T) {
 		t.Errorf("Expected result to be %+v, got %+v", expectedResponse, *result)
 	}
 }
+
+func TestGetServiceAccount(t *testing.T) {
+	// Test data
+	projectID := "proj_123"
+	serviceAccountID := "sa_456"
+	serviceAccount := ServiceAccount{
+		ID:        serviceAccountID,
+		Object:    "organization.project.service_account",
+		Name:      "test-service-account",
+		Role:      "member",
+		CreatedAt: 1617123456,
+	}
+	apiKey := ProjectAPIKey{
+		ID:            "key_456",
+		Object:        "organization.project.api_key",
+		Name:          "test-key",
+		RedactedValue: "sk-svcacct-...bbbb",
+		CreatedAt:     1617123456,
+		Owner: ProjectAPIKeyOwner{
+			Type:           OwnerTypeServiceAccount,
+			ServiceAccount: &APIKeyOwnerServiceAccount{ID: serviceAccountID},
+		},
+	}
+	serviceAccountBody, _ := json.Marshal(serviceAccount)
+	apiKeyBody, _ := json.Marshal(apiKey)
+	var paths []string
+
+	// Create mock HTTP client
+	mockClient := &MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			if req.Method != "GET" {
+				t.Errorf("Expected method to be GET, got %s", req.Method)
+			}
+			paths = append(paths, req.URL.Path)
+			var body []byte
+			switch req.URL.Path {
+			case "/v1/organization/projects/" + projectID + "/service_accounts/" + serviceAccountID:
+				body = serviceAccountBody
+			case "/v1/organization/projects/" + projectID + "/api_keys/key_456":
+				body = apiKeyBody
+			default:
+				t.Errorf("Unexpected request to %s", req.URL.String())
+			}
+			return &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(strings.NewReader(string(body))),
+			}, nil
+		},
+	}
+
+	// Create client
+	client := &Client{
+		APIKey:     "test-api-key",
+		HTTPClient: mockClient,
+		BaseURL:    "https://api.openai.com/v1/organization",
+	}
+
+	// Test GetServiceAccount
+	result, err := client.GetServiceAccount(context.Background(), projectID, serviceAccountID, "key_456")
+
+	// Verify result: the account and its key are fetched by ID
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := serviceAccount
+	expected.APIKey = ServiceAccountAPIKey{
+		Object:        "organization.project.api_key",
+		RedactedValue: "sk-svcacct-...bbbb",
+		Name:          "test-key",
+		CreatedAt:     1617123456,
+		ID:            "key_456",
+	}
+	if !reflect.DeepEqual(*result, expected) {
+		t.Errorf("Expected service account to be %+v, got %+v", expected, *result)
+	}
+	if len(paths) != 2 {
+		t.Errorf("Expected 2 requests, got %v", paths)
+	}
+
+	// Test GetServiceAccount without a key ID
+	paths = nil
+	result, err = client.GetServiceAccount(context.Background(), projectID, serviceAccountID, "")
+
+	// Verify result: only the account is fetched
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(*result, serviceAccount) {
+		t.Errorf("Expected service account to be %+v, got %+v", serviceAccount, *result)
+	}
+	if len(paths) != 1 {
+		t.Errorf("Expected 1 request, got %v", paths)
+	}
+
+	// Test GetServiceAccount with the key of another service account
+	apiKey.Owner.ServiceAccount.ID = "sa_other"
+	apiKeyBody, _ = json.Marshal(apiKey)
+	if _, err := client.GetServiceAccount(context.Background(), projectID, serviceAccountID, "key_456"); err == nil {
+		t.Error("Expected error for a key of another service account, got nil")
+	}
+}
@@ -0,0 +1,190 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Bucket widths accepted by the usage and costs APIs.
+const (
+	BucketWidthMinute = "1m"
+	BucketWidthHour   = "1h"
+	BucketWidthDay    = "1d"
+)
+
+// Fields accepted by the group_by parameter of the usage and costs APIs.
+const (
+	GroupByProjectID = "project_id"
+	GroupByUserID    = "user_id"
+	GroupByAPIKeyID  = "api_key_id"
+	GroupByModel     = "model"
+	GroupByBatch     = "batch"
+	GroupByLineItem  = "line_item"
+)
+
+// UsageClient provides access to the organization usage API.
+type UsageClient struct {
+	client *Client
+}
+
+// Usage returns a client for the organization usage API that shares the client's credentials and transport.
+func (c *Client) Usage() *UsageClient {
+	return &UsageClient{client: c}
+}
+
+// UsageQuery holds the time range, filters and grouping for a usage request.
+type UsageQuery struct {
+	StartTime   time.Time // Start of the range, inclusive (required)
+	EndTime     time.Time // End of the range, exclusive; zero means now
+	BucketWidth string    // Width of each time bucket; defaults to one day
+	ProjectIDs  []string  // Only return usage for these projects
+	UserIDs     []string  // Only return usage for these users
+	APIKeyIDs   []string  // Only return usage for these API keys
+	Models      []string  // Only return usage for these models
+	GroupBy     []string  // Fields to group results by
+	Limit       int       // Number of buckets per page
+}
+
+// values encodes the query as URL parameters.
+func (q UsageQuery) values() url.Values {
+	query := url.Values{}
+	query.Set("start_time", strconv.FormatInt(q.StartTime.Unix(), 10))
+	if !q.EndTime.IsZero() {
+		query.Set("end_time", strconv.FormatInt(q.EndTime.Unix(), 10))
+	}
+	if q.BucketWidth != "" {
+		query.Set("bucket_width", q.BucketWidth)
+	}
+	for _, id := range q.ProjectIDs {
+		query.Add("project_ids[]", id)
+	}
+	for _, id := range q.UserIDs {
+		query.Add("user_ids[]", id)
+	}
+	for _, id := range q.APIKeyIDs {
+		query.Add("api_key_ids[]", id)
+	}
+	for _, model := range q.Models {
+		query.Add("models[]", model)
+	}
+	for _, field := range q.GroupBy {
+		query.Add("group_by[]", field)
+	}
+	if q.Limit > 0 {
+		query.Set("limit", strconv.Itoa(q.Limit))
+	}
+	return query
+}
+
+// UsageBucket represents the usage within one time bucket.
+type UsageBucket struct {
+	Object    string        `json:"object"`
+	StartTime int64         `json:"start_time"`
+	EndTime   int64         `json:"end_time"`
+	Results   []UsageResult `json:"results"`
+}
+
+// UsageResult represents aggregated usage for one group within a bucket.
+// Which counters are set depends on the usage endpoint; grouping fields are empty unless grouped by.
+type UsageResult struct {
+	Object            string `json:"object"`
+	NumModelRequests  int64  `json:"num_model_requests"`
+	InputTokens       int64  `json:"input_tokens,omitempty"`
+	OutputTokens      int64  `json:"output_tokens,omitempty"`
+	InputCachedTokens int64  `json:"input_cached_tokens,omitempty"`
+	InputAudioTokens  int64  `json:"input_audio_tokens,omitempty"`
+	OutputAudioTokens int64  `json:"output_audio_tokens,omitempty"`
+	Images            int64  `json:"images,omitempty"`
+	Source            string `json:"source,omitempty"`
+	Size              string `json:"size,omitempty"`
+	Characters        int64  `json:"characters,omitempty"`
+	Seconds           int64  `json:"seconds,omitempty"`
+	ProjectID         string `json:"project_id,omitempty"`
+	UserID            string `json:"user_id,omitempty"`
+	APIKeyID          string `json:"api_key_id,omitempty"`
+	Model             string `json:"model,omitempty"`
+	Batch             *bool  `json:"batch,omitempty"`
+}
+
+// UsageResponse represents a page of buckets from a usage API.
+type UsageResponse struct {
+	Object   string        `json:"object"`
+	Data     []UsageBucket `json:"data"`
+	HasMore  bool          `json:"has_more"`
+	NextPage string        `json:"next_page"`
+}
+
+// Usage endpoints, for use with UsageClient.Buckets.
+const (
+	UsageCompletions         = "completions"
+	UsageEmbeddings          = "embeddings"
+	UsageImages              = "images"
+	UsageAudioSpeeches       = "audio_speeches"
+	UsageAudioTranscriptions = "audio_transcriptions"
+	UsageModerations         = "moderations"
+)
+
+// Completions retrieves completions usage.
+func (u *UsageClient) Completions(ctx context.Context, q UsageQuery) (*[]UsageBucket, error) {
+	return collect(u.Buckets(ctx, UsageCompletions, q))
+}
+
+// Embeddings retrieves embeddings usage.
+func (u *UsageClient) Embeddings(ctx context.Context, q UsageQuery) (*[]UsageBucket, error) {
+	return collect(u.Buckets(ctx, UsageEmbeddings, q))
+}
+
+// Images retrieves image generation usage.
+func (u *UsageClient) Images(ctx context.Context, q UsageQuery) (*[]UsageBucket, error) {
+	return collect(u.Buckets(ctx, UsageImages, q))
+}
+
+// AudioSpeeches retrieves text-to-speech usage.
+func (u *UsageClient) AudioSpeeches(ctx context.Context, q UsageQuery) (*[]UsageBucket, error) {
+	return collect(u.Buckets(ctx, UsageAudioSpeeches, q))
+}
+
+// AudioTranscriptions retrieves speech-to-text usage.
+func (u *UsageClient) AudioTranscriptions(ctx context.Context, q UsageQuery) (*[]UsageBucket, error) {
+	return collect(u.Buckets(ctx, UsageAudioTranscriptions, q))
+}
+
+// Moderations retrieves moderations usage.
+func (u *UsageClient) Moderations(ctx context.Context, q UsageQuery) (*[]UsageBucket, error) {
+	return collect(u.Buckets(ctx, UsageModerations, q))
+}
+
+// Buckets returns an iterator over the usage buckets of a usage endpoint.
+func (u *UsageClient) Buckets(ctx context.Context, endpoint string, q UsageQuery) iter.Seq2[UsageBucket, error] {
+	return paginate(func(page string) ([]UsageBucket, string, bool, error) {
+		resp, err := u.listPage(ctx, endpoint, q, page)
+		if err != nil {
+			return nil, "", false, fmt.Errorf("get %s usage: %w", endpoint, err)
+		}
+		return resp.Data, resp.NextPage, resp.HasMore, nil
+	})
+}
+
+// listPage retrieves a page of usage buckets starting at the given page cursor.
+func (u *UsageClient) listPage(ctx context.Context, endpoint string, q UsageQuery, page string) (*UsageResponse, error) {
+	query := q.values()
+	if page != "" {
+		query.Set("page", page)
+	}
+
+	respBody, err := u.client.doRequest(ctx, "GET", "/usage/"+endpoint, query, nil)
+	if err != nil {
+		return nil, fmt.Errorf("execute request: %w", err)
+	}
+	var result UsageResponse
+	err = json.Unmarshal(respBody, &result)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal json: %w", err)
+	}
+	return &result, nil
+}
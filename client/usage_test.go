@@ -0,0 +1,181 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestUsageQueryValues(t *testing.T) {
+	q := UsageQuery{
+		StartTime:   time.Unix(1730419200, 0),
+		EndTime:     time.Unix(1730505600, 0),
+		BucketWidth: BucketWidthHour,
+		ProjectIDs:  []string{"proj_123"},
+		APIKeyIDs:   []string{"key_123", "key_456"},
+		Models:      []string{"gpt-4o"},
+		GroupBy:     []string{GroupByAPIKeyID, GroupByModel},
+		Limit:       24,
+	}
+
+	values := q.values()
+
+	expected := map[string][]string{
+		"start_time":    {"1730419200"},
+		"end_time":      {"1730505600"},
+		"bucket_width":  {"1h"},
+		"project_ids[]": {"proj_123"},
+		"api_key_ids[]": {"key_123", "key_456"},
+		"models[]":      {"gpt-4o"},
+		"group_by[]":    {"api_key_id", "model"},
+		"limit":         {"24"},
+	}
+	if !reflect.DeepEqual(map[string][]string(values), expected) {
+		t.Errorf("Expected query values to be %v, got %v", expected, values)
+	}
+}
+
+func TestUsageCompletions_Pagination(t *testing.T) {
+	// Test data
+	firstPageBody := `{
+		"object": "page",
+		"data": [{
+			"object": "bucket",
+			"start_time": 1730419200,
+			"end_time": 1730505600,
+			"results": [{
+				"object": "organization.usage.completions.result",
+				"input_tokens": 1000,
+				"output_tokens": 500,
+				"input_cached_tokens": 800,
+				"input_audio_tokens": 0,
+				"output_audio_tokens": 0,
+				"num_model_requests": 5,
+				"project_id": null,
+				"user_id": null,
+				"api_key_id": "key_123",
+				"model": null,
+				"batch": null
+			}]
+		}],
+		"has_more": true,
+		"next_page": "page_AAAAAGdGxdEiJdKOAAAAAGcqsYA="
+	}`
+	secondPageBody := `{
+		"object": "page",
+		"data": [{
+			"object": "bucket",
+			"start_time": 1730505600,
+			"end_time": 1730592000,
+			"results": []
+		}],
+		"has_more": false,
+		"next_page": null
+	}`
+
+	// Create mock HTTP client
+	callCount := 0
+	mockClient := &MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			callCount++
+			if req.URL.Path != "/v1/organization/usage/completions" {
+				t.Errorf("Expected path to be /v1/organization/usage/completions, got %s", req.URL.Path)
+			}
+			body := firstPageBody
+			if callCount == 1 {
+				if req.URL.Query().Get("page") != "" {
+					t.Errorf("Expected no 'page' query parameter on first call, got '%s'", req.URL.Query().Get("page"))
+				}
+			} else {
+				if req.URL.Query().Get("page") != "page_AAAAAGdGxdEiJdKOAAAAAGcqsYA=" {
+					t.Errorf("Expected 'page' query parameter to be the next page cursor, got '%s'", req.URL.Query().Get("page"))
+				}
+				body = secondPageBody
+			}
+			return &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(strings.NewReader(body)),
+			}, nil
+		},
+	}
+
+	// Create client
+	client := &Client{
+		APIKey:     "test-api-key",
+		HTTPClient: mockClient,
+		BaseURL:    "https://api.openai.com/v1/organization",
+	}
+
+	// Test Completions
+	buckets, err := client.Usage().Completions(context.Background(), UsageQuery{
+		StartTime: time.Unix(1730419200, 0),
+		GroupBy:   []string{GroupByAPIKeyID},
+	})
+
+	// Verify result
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(*buckets) != 2 {
+		t.Fatalf("Expected 2 buckets, got %d", len(*buckets))
+	}
+	result := (*buckets)[0].Results[0]
+	if result.APIKeyID != "key_123" || result.InputTokens != 1000 || result.NumModelRequests != 5 {
+		t.Errorf("Expected result for key_123 with 1000 input tokens and 5 requests, got %+v", result)
+	}
+	if callCount != 2 {
+		t.Errorf("Expected 2 API calls, got %d", callCount)
+	}
+}
+
+func TestUsageEndpoints(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		call func(u *UsageClient) (*[]UsageBucket, error)
+	}{
+		{name: "Embeddings", path: "/v1/organization/usage/embeddings", call: func(u *UsageClient) (*[]UsageBucket, error) {
+			return u.Embeddings(context.Background(), UsageQuery{})
+		}},
+		{name: "Images", path: "/v1/organization/usage/images", call: func(u *UsageClient) (*[]UsageBucket, error) {
+			return u.Images(context.Background(), UsageQuery{})
+		}},
+		{name: "AudioSpeeches", path: "/v1/organization/usage/audio_speeches", call: func(u *UsageClient) (*[]UsageBucket, error) {
+			return u.AudioSpeeches(context.Background(), UsageQuery{})
+		}},
+		{name: "AudioTranscriptions", path: "/v1/organization/usage/audio_transcriptions", call: func(u *UsageClient) (*[]UsageBucket, error) {
+			return u.AudioTranscriptions(context.Background(), UsageQuery{})
+		}},
+		{name: "Moderations", path: "/v1/organization/usage/moderations", call: func(u *UsageClient) (*[]UsageBucket, error) {
+			return u.Moderations(context.Background(), UsageQuery{})
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &Client{
+				APIKey: "test-api-key",
+				HTTPClient: &MockHTTPClient{
+					DoFunc: func(req *http.Request) (*http.Response, error) {
+						if req.URL.Path != tt.path {
+							t.Errorf("Expected path to be %s, got %s", tt.path, req.URL.Path)
+						}
+						return &http.Response{
+							StatusCode: 200,
+							Body:       io.NopCloser(strings.NewReader(`{"object":"page","data":[],"has_more":false}`)),
+						}, nil
+					},
+				},
+				BaseURL: "https://api.openai.com/v1/organization",
+			}
+
+			if _, err := tt.call(client.Usage()); err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,140 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"net/url"
+	"strconv"
+)
+
+// Organization roles.
+const (
+	OrganizationRoleOwner  = "owner"
+	OrganizationRoleReader = "reader"
+)
+
+// User represents a member of the OpenAI organization.
+type User struct {
+	ID      string `json:"id"`
+	Object  string `json:"object"`
+	Name    string `json:"name"`
+	Email   string `json:"email"`
+	Role    string `json:"role"`
+	AddedAt int64  `json:"added_at"`
+}
+
+// ListUserResponse represents the response from the list users API.
+type ListUserResponse struct {
+	Object  string `json:"object"`
+	Data    []User `json:"data"`
+	FirstID string `json:"first_id"`
+	LastID  string `json:"last_id"`
+	HasMore bool   `json:"has_more"`
+}
+
+// DeletedUserResponse represents the response from the delete user API.
+type DeletedUserResponse struct {
+	Object  string `json:"object"`
+	ID      string `json:"id"`
+	Deleted bool   `json:"deleted"`
+}
+
+// ListUsers retrieves all users in the organization.
+func (c *Client) ListUsers(ctx context.Context) (*[]User, error) {
+	return collect(c.Users(ctx))
+}
+
+// Users returns an iterator over all users in the organization.
+func (c *Client) Users(ctx context.Context) iter.Seq2[User, error] {
+	const pageSize = 100
+	return paginate(func(after string) ([]User, string, bool, error) {
+		resp, err := c.listUsers(ctx, nil, after, pageSize)
+		if err != nil {
+			return nil, "", false, fmt.Errorf("get user list: %w", err)
+		}
+		return resp.Data, resp.LastID, resp.HasMore, nil
+	})
+}
+
+// GetUserByEmail retrieves an organization user by email, returning the user, a boolean indicating if it was found, and any error.
+func (c *Client) GetUserByEmail(ctx context.Context, email string) (*User, bool, error) {
+	resp, err := c.listUsers(ctx, []string{email}, "", 1)
+	if err != nil {
+		return nil, false, fmt.Errorf("get user list: %w", err)
+	}
+	for _, user := range resp.Data {
+		if user.Email == email {
+			return &user, true, nil
+		}
+	}
+	return nil, false, nil
+}
+
+// listUsers retrieves a page of users with filtering and pagination options.
+func (c *Client) listUsers(ctx context.Context, emails []string, after string, limit int) (*ListUserResponse, error) {
+	query := url.Values{}
+	for _, email := range emails {
+		query.Add("emails[]", email)
+	}
+	if after != "" {
+		query.Set("after", after)
+	}
+	if limit > 0 {
+		query.Set("limit", strconv.Itoa(limit))
+	}
+
+	respBody, err := c.doRequest(ctx, "GET", "/users", query, nil)
+	if err != nil {
+		return nil, fmt.Errorf("execute request: %w", err)
+	}
+	var result ListUserResponse
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		return nil, fmt.Errorf("unmarshal json: %w", err)
+	}
+	return &result, nil
+}
+
+// GetUser retrieves an organization user by ID.
+func (c *Client) GetUser(ctx context.Context, userID string) (*User, error) {
+	respBody, err := c.doRequest(ctx, "GET", fmt.Sprintf("/users/%s", userID), nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("get user: %w", err)
+	}
+	var user User
+	err = json.Unmarshal(respBody, &user)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal json: %w", err)
+	}
+	return &user, nil
+}
+
+// ModifyUserRole changes the organization role of a user.
+func (c *Client) ModifyUserRole(ctx context.Context, userID string, role string) (*User, error) {
+	body := map[string]string{"role": role}
+	respBody, err := c.doRequest(WithIdempotent(ctx), "POST", fmt.Sprintf("/users/%s", userID), nil, body)
+	if err != nil {
+		return nil, fmt.Errorf("modify user: %w", err)
+	}
+	var user User
+	err = json.Unmarshal(respBody, &user)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal json: %w", err)
+	}
+	return &user, nil
+}
+
+// DeleteUser removes a user from the organization.
+func (c *Client) DeleteUser(ctx context.Context, userID string) (*DeletedUserResponse, error) {
+	respBody, err := c.doRequest(ctx, "DELETE", fmt.Sprintf("/users/%s", userID), nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("delete user: %w", err)
+	}
+	var result DeletedUserResponse
+	err = json.Unmarshal(respBody, &result)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal json: %w", err)
+	}
+	return &result, nil
+}
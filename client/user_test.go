@@ -0,0 +1,229 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestListUsers_Pagination(t *testing.T) {
+	// Test data
+	firstPageUsers := []User{{ID: "user_123", Object: "organization.user", Name: "First", Email: "first@example.com", Role: OrganizationRoleOwner, AddedAt: 1711471533}}
+	firstPageBody, _ := json.Marshal(ListUserResponse{Object: "list", Data: firstPageUsers, FirstID: "user_123", LastID: "user_123", HasMore: true})
+	secondPageUsers := []User{{ID: "user_456", Object: "organization.user", Name: "Second", Email: "second@example.com", Role: OrganizationRoleReader, AddedAt: 1711471533}}
+	secondPageBody, _ := json.Marshal(ListUserResponse{Object: "list", Data: secondPageUsers, FirstID: "user_456", LastID: "user_456", HasMore: false})
+
+	// Create mock HTTP client
+	callCount := 0
+	mockClient := &MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			callCount++
+			if req.URL.Path != "/v1/organization/users" {
+				t.Errorf("Expected path to be /v1/organization/users, got %s", req.URL.Path)
+			}
+			body := firstPageBody
+			if callCount == 2 {
+				if req.URL.Query().Get("after") != "user_123" {
+					t.Errorf("Expected 'after' query parameter to be 'user_123' on second call, got '%s'", req.URL.Query().Get("after"))
+				}
+				body = secondPageBody
+			}
+			return &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(strings.NewReader(string(body))),
+			}, nil
+		},
+	}
+
+	// Create client
+	client := &Client{
+		APIKey:     "test-api-key",
+		HTTPClient: mockClient,
+		BaseURL:    "https://api.openai.com/v1/organization",
+	}
+
+	// Test ListUsers
+	users, err := client.ListUsers(context.Background())
+
+	// Verify result
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	expectedUsers := append(firstPageUsers, secondPageUsers...)
+	if !reflect.DeepEqual(*users, expectedUsers) {
+		t.Errorf("Expected users to be %+v, got %+v", expectedUsers, *users)
+	}
+}
+
+func TestGetUserByEmail(t *testing.T) {
+	tests := []struct {
+		name          string
+		responseUsers []User
+		expectedFound bool
+	}{
+		{
+			name:          "Found",
+			responseUsers: []User{{ID: "user_123", Email: "user@example.com"}},
+			expectedFound: true,
+		},
+		{
+			name:          "Not found",
+			responseUsers: []User{},
+			expectedFound: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			responseBody, _ := json.Marshal(ListUserResponse{Object: "list", Data: tt.responseUsers})
+			client := &Client{
+				APIKey: "test-api-key",
+				HTTPClient: &MockHTTPClient{
+					DoFunc: func(req *http.Request) (*http.Response, error) {
+						if !reflect.DeepEqual(req.URL.Query()["emails[]"], []string{"user@example.com"}) {
+							t.Errorf("Expected 'emails[]' query parameter to be [user@example.com], got %v", req.URL.Query()["emails[]"])
+						}
+						return &http.Response{
+							StatusCode: 200,
+							Body:       io.NopCloser(strings.NewReader(string(responseBody))),
+						}, nil
+					},
+				},
+				BaseURL: "https://api.openai.com/v1/organization",
+			}
+
+			user, found, err := client.GetUserByEmail(context.Background(), "user@example.com")
+			if err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+			if found != tt.expectedFound {
+				t.Errorf("Expected found to be %v, got %v", tt.expectedFound, found)
+			}
+			if found && user.ID != "user_123" {
+				t.Errorf("Expected user ID to be 'user_123', got '%s'", user.ID)
+			}
+		})
+	}
+}
+
+func TestUserRequests(t *testing.T) {
+	tests := []struct {
+		name           string
+		expectedMethod string
+		expectedPath   string
+		expectedBody   string
+		responseBody   string
+		call           func(c *Client) (interface{}, error)
+	}{
+		{
+			name:           "GetUser",
+			expectedMethod: "GET",
+			expectedPath:   "/v1/organization/users/user_123",
+			responseBody:   `{"object":"organization.user","id":"user_123","email":"user@example.com","role":"reader"}`,
+			call: func(c *Client) (interface{}, error) {
+				return c.GetUser(context.Background(), "user_123")
+			},
+		},
+		{
+			name:           "ModifyUserRole",
+			expectedMethod: "POST",
+			expectedPath:   "/v1/organization/users/user_123",
+			expectedBody:   `{"role":"owner"}`,
+			responseBody:   `{"object":"organization.user","id":"user_123","email":"user@example.com","role":"owner"}`,
+			call: func(c *Client) (interface{}, error) {
+				return c.ModifyUserRole(context.Background(), "user_123", OrganizationRoleOwner)
+			},
+		},
+		{
+			name:           "DeleteUser",
+			expectedMethod: "DELETE",
+			expectedPath:   "/v1/organization/users/user_123",
+			responseBody:   `{"object":"organization.user.deleted","id":"user_123","deleted":true}`,
+			call: func(c *Client) (interface{}, error) {
+				return c.DeleteUser(context.Background(), "user_123")
+			},
+		},
+		{
+			name:           "CreateInvite",
+			expectedMethod: "POST",
+			expectedPath:   "/v1/organization/invites",
+			expectedBody:   `{"email":"user@example.com","role":"reader","projects":[{"id":"proj_123","role":"member"}]}`,
+			responseBody:   `{"object":"organization.invite","id":"invite-123","email":"user@example.com","role":"reader","status":"pending","projects":[{"id":"proj_123","role":"member"}]}`,
+			call: func(c *Client) (interface{}, error) {
+				return c.CreateInvite(context.Background(), CreateInviteRequest{
+					Email:    "user@example.com",
+					Role:     OrganizationRoleReader,
+					Projects: []InviteProject{{ID: "proj_123", Role: ProjectRoleMember}},
+				})
+			},
+		},
+		{
+			name:           "GetInvite",
+			expectedMethod: "GET",
+			expectedPath:   "/v1/organization/invites/invite-123",
+			responseBody:   `{"object":"organization.invite","id":"invite-123","email":"user@example.com","status":"accepted","accepted_at":1711471533}`,
+			call: func(c *Client) (interface{}, error) {
+				return c.GetInvite(context.Background(), "invite-123")
+			},
+		},
+		{
+			name:           "DeleteInvite",
+			expectedMethod: "DELETE",
+			expectedPath:   "/v1/organization/invites/invite-123",
+			responseBody:   `{"object":"organization.invite.deleted","id":"invite-123","deleted":true}`,
+			call: func(c *Client) (interface{}, error) {
+				return c.DeleteInvite(context.Background(), "invite-123")
+			},
+		},
+		{
+			name:           "ListInvites",
+			expectedMethod: "GET",
+			expectedPath:   "/v1/organization/invites",
+			responseBody:   `{"object":"list","data":[{"object":"organization.invite","id":"invite-123"}],"has_more":false}`,
+			call: func(c *Client) (interface{}, error) {
+				return c.ListInvites(context.Background())
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &Client{
+				APIKey: "test-api-key",
+				HTTPClient: &MockHTTPClient{
+					DoFunc: func(req *http.Request) (*http.Response, error) {
+						if req.Method != tt.expectedMethod {
+							t.Errorf("Expected method to be %s, got %s", tt.expectedMethod, req.Method)
+						}
+						if req.URL.Path != tt.expectedPath {
+							t.Errorf("Expected path to be %s, got %s", tt.expectedPath, req.URL.Path)
+						}
+						if tt.expectedBody != "" {
+							body, _ := io.ReadAll(req.Body)
+							if string(body) != tt.expectedBody {
+								t.Errorf("Expected request body to be %s, got %s", tt.expectedBody, string(body))
+							}
+						}
+						return &http.Response{
+							StatusCode: 200,
+							Body:       io.NopCloser(strings.NewReader(tt.responseBody)),
+						}, nil
+					},
+				},
+				BaseURL: "https://api.openai.com/v1/organization",
+			}
+
+			result, err := tt.call(client)
+			if err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+			if result == nil {
+				t.Error("Expected non-nil result")
+			}
+		})
+	}
+}
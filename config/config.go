@@ -10,19 +10,41 @@ import (
 
 // Config holds application configuration loaded from environment variables.
 type Config struct {
-	AllowedUsers         string `envconfig:"ALLOWED_USERS"`
-	AllowedDomains       string `envconfig:"ALLOWED_DOMAINS"`
-	OpenAIManagementKey  string `envconfig:"OPENAI_MANAGEMENT_KEY"`
-	ClientID             string `envconfig:"CLIENT_ID"`
-	ClientSecret         string `envconfig:"CLIENT_SECRET"`
-	RedirectURI          string `envconfig:"REDIRECT_URI"`
-	DefaultProjectName   string `envconfig:"DEFAULT_PROJECT_NAME" default:"personal"`
-	Port                 string `envconfig:"PORT" default:"8080"`
-	Expiration           int    `envconfig:"EXPIRATION" default:"86400"`      // 24 hours
-	CleanupInterval      int    `envconfig:"CLEANUP_INTERVAL" default:"3600"` // 1 hour
-	Timeout              int    `envconfig:"TIMEOUT" default:"10"`            // 10 seconds
-	GoogleTokenIssuerURL string `envconfig:"GOOGLE_TOKEN_ISSUER_URL" default:"https://accounts.google.com"`
-	GoogleTokenJwksURL   string `envconfig:"GOOGLE_TOKEN_AUDIENCE" default:"https://www.googleapis.com/oauth2/v3/certs"`
+	AllowedUsers                string  `envconfig:"ALLOWED_USERS"`
+	AllowedDomains              string  `envconfig:"ALLOWED_DOMAINS"`
+	OpenAIManagementKey         string  `envconfig:"OPENAI_MANAGEMENT_KEY"`
+	OpenAIBaseURL               string  `envconfig:"OPENAI_BASE_URL" default:"https://api.openai.com/v1/organization"`
+	ClientID                    string  `envconfig:"CLIENT_ID"`
+	ClientSecret                string  `envconfig:"CLIENT_SECRET"`
+	RedirectURI                 string  `envconfig:"REDIRECT_URI"`
+	DefaultProjectName          string  `envconfig:"DEFAULT_PROJECT_NAME" default:"personal"`
+	Port                        string  `envconfig:"PORT" default:"8080"`
+	Expiration                  int     `envconfig:"EXPIRATION" default:"86400"`      // 24 hours
+	KeyTTLMin                   int     `envconfig:"KEY_TTL_MIN" default:"0"`         // no lower bound
+	KeyTTLMax                   int     `envconfig:"KEY_TTL_MAX" default:"0"`         // same as EXPIRATION
+	CleanupInterval             int     `envconfig:"CLEANUP_INTERVAL" default:"3600"` // 1 hour
+	CleanupDryRun               bool    `envconfig:"CLEANUP_DRY_RUN" default:"false"`
+	Timeout                     int     `envconfig:"TIMEOUT" default:"10"` // 10 seconds
+	MaxRetries                  int     `envconfig:"MAX_RETRIES" default:"3"`
+	OpenAIRateLimit             float64 `envconfig:"OPENAI_RATE_LIMIT" default:"5"` // requests per second
+	OpenAIRateBurst             int     `envconfig:"OPENAI_RATE_BURST" default:"10"`
+	ProjectCacheTTL             int     `envconfig:"PROJECT_CACHE_TTL" default:"300"` // 5 minutes
+	OpenAIStrictDecoding        bool    `envconfig:"OPENAI_STRICT_DECODING" default:"false"`
+	BreakerFailureThreshold     int     `envconfig:"BREAKER_FAILURE_THRESHOLD" default:"5"`
+	BreakerOpenTimeout          int     `envconfig:"BREAKER_OPEN_TIMEOUT" default:"30"` // 30 seconds
+	MetricsPath                 string  `envconfig:"METRICS_PATH"`
+	KeyStore                    string  `envconfig:"KEY_STORE" default:"memory"`
+	KeyStorePath                string  `envconfig:"KEY_STORE_PATH"`
+	OutboundProxyURL            string  `envconfig:"OUTBOUND_PROXY_URL"`
+	OutboundCAFiles             string  `envconfig:"OUTBOUND_CA_FILES"`
+	OutboundClientCertFile      string  `envconfig:"OUTBOUND_CLIENT_CERT_FILE"`
+	OutboundClientKeyFile       string  `envconfig:"OUTBOUND_CLIENT_KEY_FILE"`
+	OutboundMaxIdleConns        int     `envconfig:"OUTBOUND_MAX_IDLE_CONNS" default:"100"`
+	OutboundMaxIdleConnsPerHost int     `envconfig:"OUTBOUND_MAX_IDLE_CONNS_PER_HOST" default:"10"`
+	OutboundMaxConnsPerHost     int     `envconfig:"OUTBOUND_MAX_CONNS_PER_HOST" default:"0"`
+	OutboundIdleConnTimeout     int     `envconfig:"OUTBOUND_IDLE_CONN_TIMEOUT" default:"90"` // 90 seconds
+	GoogleTokenIssuerURL        string  `envconfig:"GOOGLE_TOKEN_ISSUER_URL" default:"https://accounts.google.com"`
+	GoogleTokenJwksURL          string  `envconfig:"GOOGLE_TOKEN_AUDIENCE" default:"https://www.googleapis.com/oauth2/v3/certs"`
 }
 
 // NewConfig creates and validates a new configuration from environment variables.
@@ -46,6 +68,51 @@ func NewConfig() (*Config, error) {
 	if config.RedirectURI == "" {
 		return nil, fmt.Errorf("REDIRECT_URI is required")
 	}
+	if config.KeyTTLMin < 0 || config.KeyTTLMax < 0 {
+		return nil, fmt.Errorf("KEY_TTL_MIN and KEY_TTL_MAX must not be negative")
+	}
+	if config.KeyTTLMin > config.Expiration {
+		return nil, fmt.Errorf("KEY_TTL_MIN must not exceed EXPIRATION")
+	}
+	if config.KeyTTLMax > 0 && config.KeyTTLMax < config.Expiration {
+		return nil, fmt.Errorf("KEY_TTL_MAX must not be less than EXPIRATION")
+	}
+	if config.MaxRetries < 0 {
+		return nil, fmt.Errorf("MAX_RETRIES must not be negative")
+	}
+	if config.OpenAIRateLimit < 0 {
+		return nil, fmt.Errorf("OPENAI_RATE_LIMIT must not be negative")
+	}
+	if config.OpenAIRateLimit > 0 && config.OpenAIRateBurst < 1 {
+		return nil, fmt.Errorf("OPENAI_RATE_BURST must be at least 1")
+	}
+	if config.ProjectCacheTTL < 0 {
+		return nil, fmt.Errorf("PROJECT_CACHE_TTL must not be negative")
+	}
+	if config.BreakerFailureThreshold < 0 {
+		return nil, fmt.Errorf("BREAKER_FAILURE_THRESHOLD must not be negative")
+	}
+	if config.BreakerOpenTimeout < 0 {
+		return nil, fmt.Errorf("BREAKER_OPEN_TIMEOUT must not be negative")
+	}
+	if config.MetricsPath != "" && !strings.HasPrefix(config.MetricsPath, "/") {
+		return nil, fmt.Errorf("METRICS_PATH must start with /")
+	}
+	switch config.KeyStore {
+	case "memory":
+	case "file", "sqlite":
+		if config.KeyStorePath == "" {
+			return nil, fmt.Errorf("KEY_STORE_PATH is required for the %s key store", config.KeyStore)
+		}
+	default:
+		return nil, fmt.Errorf("KEY_STORE must be memory, file or sqlite")
+	}
+	if (config.OutboundClientCertFile == "") != (config.OutboundClientKeyFile == "") {
+		return nil, fmt.Errorf("OUTBOUND_CLIENT_CERT_FILE and OUTBOUND_CLIENT_KEY_FILE must be set together")
+	}
+	if config.OutboundMaxIdleConns < 0 || config.OutboundMaxIdleConnsPerHost < 0 || config.OutboundMaxConnsPerHost < 0 || config.OutboundIdleConnTimeout < 0 {
+		return nil, fmt.Errorf("OUTBOUND connection pool limits must not be negative")
+	}
 	return config, nil
 }
 
@@ -79,6 +146,11 @@ func (c *Config) GetOpenAIManagementKey() string {
 	return c.OpenAIManagementKey
 }
 
+// GetOpenAIBaseURL returns the base URL of the OpenAI Admin API.
+func (c *Config) GetOpenAIBaseURL() string {
+	return c.OpenAIBaseURL
+}
+
 // GetClientID returns the OAuth client ID.
 func (c *Config) GetClientID() string {
 	return c.ClientID
@@ -109,16 +181,129 @@ func (c *Config) GetExpiration() time.Duration {
 	return time.Duration(c.Expiration) * time.Second
 }
 
+// GetKeyTTLMin returns the shortest key lifetime a user may request; zero means no lower bound.
+func (c *Config) GetKeyTTLMin() time.Duration {
+	return time.Duration(c.KeyTTLMin) * time.Second
+}
+
+// GetKeyTTLMax returns the longest key lifetime a user may request, defaulting to the expiration.
+func (c *Config) GetKeyTTLMax() time.Duration {
+	if c.KeyTTLMax == 0 {
+		return c.GetExpiration()
+	}
+	return time.Duration(c.KeyTTLMax) * time.Second
+}
+
 // GetCleanupInterval returns the interval for API key cleanup operations.
 func (c *Config) GetCleanupInterval() time.Duration {
 	return time.Duration(c.CleanupInterval) * time.Second
 }
 
+// GetCleanupDryRun reports whether periodic cleanup only reports the keys it would delete.
+func (c *Config) GetCleanupDryRun() bool {
+	return c.CleanupDryRun
+}
+
 // GetTimeout returns the HTTP client timeout duration.
 func (c *Config) GetTimeout() time.Duration {
 	return time.Duration(c.Timeout) * time.Second
 }
 
+// GetMaxRetries returns the maximum number of retries for failed OpenAI API requests.
+func (c *Config) GetMaxRetries() int {
+	return c.MaxRetries
+}
+
+// GetOpenAIRateLimit returns the client-side rate limit for OpenAI API requests per second.
+func (c *Config) GetOpenAIRateLimit() float64 {
+	return c.OpenAIRateLimit
+}
+
+// GetOpenAIRateBurst returns the maximum burst of OpenAI API requests.
+func (c *Config) GetOpenAIRateBurst() int {
+	return c.OpenAIRateBurst
+}
+
+// GetProjectCacheTTL returns how long project lookups are cached.
+func (c *Config) GetProjectCacheTTL() time.Duration {
+	return time.Duration(c.ProjectCacheTTL) * time.Second
+}
+
+// GetOpenAIStrictDecoding returns whether OpenAI API responses are checked for schema drift.
+func (c *Config) GetOpenAIStrictDecoding() bool {
+	return c.OpenAIStrictDecoding
+}
+
+// GetBreakerFailureThreshold returns the number of consecutive failures that open the circuit breaker.
+func (c *Config) GetBreakerFailureThreshold() int {
+	return c.BreakerFailureThreshold
+}
+
+// GetBreakerOpenTimeout returns how long the circuit breaker stays open before probing.
+func (c *Config) GetBreakerOpenTimeout() time.Duration {
+	return time.Duration(c.BreakerOpenTimeout) * time.Second
+}
+
+// GetMetricsPath returns the path serving metrics, or an empty string if metrics are disabled.
+func (c *Config) GetMetricsPath() string {
+	return c.MetricsPath
+}
+
+// GetKeyStore returns the kind of store that records issued keys: memory, file or sqlite.
+func (c *Config) GetKeyStore() string {
+	return c.KeyStore
+}
+
+// GetKeyStorePath returns the path of the file or database that records issued keys.
+func (c *Config) GetKeyStorePath() string {
+	return c.KeyStorePath
+}
+
+// GetOutboundProxyURL returns the proxy URL for outbound requests.
+func (c *Config) GetOutboundProxyURL() string {
+	return c.OutboundProxyURL
+}
+
+// GetOutboundCAFiles returns the list of extra CA bundle files for outbound requests.
+func (c *Config) GetOutboundCAFiles() *[]string {
+	if c.OutboundCAFiles == "" {
+		empty := []string{}
+		return &empty
+	}
+	result := strings.Split(c.OutboundCAFiles, ",")
+	return &result
+}
+
+// GetOutboundClientCertFile returns the client certificate file for outbound mutual TLS.
+func (c *Config) GetOutboundClientCertFile() string {
+	return c.OutboundClientCertFile
+}
+
+// GetOutboundClientKeyFile returns the client key file for outbound mutual TLS.
+func (c *Config) GetOutboundClientKeyFile() string {
+	return c.OutboundClientKeyFile
+}
+
+// GetOutboundMaxIdleConns returns the maximum number of idle outbound connections.
+func (c *Config) GetOutboundMaxIdleConns() int {
+	return c.OutboundMaxIdleConns
+}
+
+// GetOutboundMaxIdleConnsPerHost returns the maximum number of idle outbound connections per host.
+func (c *Config) GetOutboundMaxIdleConnsPerHost() int {
+	return c.OutboundMaxIdleConnsPerHost
+}
+
+// GetOutboundMaxConnsPerHost returns the maximum number of outbound connections per host.
+func (c *Config) GetOutboundMaxConnsPerHost() int {
+	return c.OutboundMaxConnsPerHost
+}
+
+// GetOutboundIdleConnTimeout returns how long idle outbound connections are kept.
+func (c *Config) GetOutboundIdleConnTimeout() time.Duration {
+	return time.Duration(c.OutboundIdleConnTimeout) * time.Second
+}
+
 // GetGoogleTokenIssuerURL returns the Google token issuer URL.
 func (c *Config) GetGoogleTokenIssuerURL() string {
 	return c.GoogleTokenIssuerURL
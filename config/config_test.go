@@ -17,8 +17,20 @@ func TestNewConfig(t *testing.T) {
 	origDefaultProjectName := os.Getenv("DEFAULT_PROJECT_NAME")
 	origPort := os.Getenv("PORT")
 	origExpiration := os.Getenv("EXPIRATION")
+	origKeyTTLMin := os.Getenv("KEY_TTL_MIN")
+	origKeyTTLMax := os.Getenv("KEY_TTL_MAX")
 	origCleanupInterval := os.Getenv("CLEANUP_INTERVAL")
 	origTimeout := os.Getenv("TIMEOUT")
+	origMaxRetries := os.Getenv("MAX_RETRIES")
+	origOpenAIRateLimit := os.Getenv("OPENAI_RATE_LIMIT")
+	origOpenAIRateBurst := os.Getenv("OPENAI_RATE_BURST")
+	origProjectCacheTTL := os.Getenv("PROJECT_CACHE_TTL")
+	origBreakerFailureThreshold := os.Getenv("BREAKER_FAILURE_THRESHOLD")
+	origMetricsPath := os.Getenv("METRICS_PATH")
+	origKeyStore := os.Getenv("KEY_STORE")
+	origKeyStorePath := os.Getenv("KEY_STORE_PATH")
+	origOutboundClientCertFile := os.Getenv("OUTBOUND_CLIENT_CERT_FILE")
+	origOutboundMaxConnsPerHost := os.Getenv("OUTBOUND_MAX_CONNS_PER_HOST")
 
 	// Restore environment variables after test
 	defer func() {
@@ -31,8 +43,20 @@ func TestNewConfig(t *testing.T) {
 		os.Setenv("DEFAULT_PROJECT_NAME", origDefaultProjectName)
 		os.Setenv("PORT", origPort)
 		os.Setenv("EXPIRATION", origExpiration)
+		os.Setenv("KEY_TTL_MIN", origKeyTTLMin)
+		os.Setenv("KEY_TTL_MAX", origKeyTTLMax)
 		os.Setenv("CLEANUP_INTERVAL", origCleanupInterval)
 		os.Setenv("TIMEOUT", origTimeout)
+		os.Setenv("MAX_RETRIES", origMaxRetries)
+		os.Setenv("OPENAI_RATE_LIMIT", origOpenAIRateLimit)
+		os.Setenv("OPENAI_RATE_BURST", origOpenAIRateBurst)
+		os.Setenv("PROJECT_CACHE_TTL", origProjectCacheTTL)
+		os.Setenv("BREAKER_FAILURE_THRESHOLD", origBreakerFailureThreshold)
+		os.Setenv("METRICS_PATH", origMetricsPath)
+		os.Setenv("KEY_STORE", origKeyStore)
+		os.Setenv("KEY_STORE_PATH", origKeyStorePath)
+		os.Setenv("OUTBOUND_CLIENT_CERT_FILE", origOutboundClientCertFile)
+		os.Setenv("OUTBOUND_MAX_CONNS_PER_HOST", origOutboundMaxConnsPerHost)
 	}()
 
 	tests := []struct {
@@ -148,11 +172,170 @@ func TestNewConfig(t *testing.T) {
 				os.Setenv("DEFAULT_PROJECT_NAME", "custom-project")
 				os.Setenv("PORT", "9000")
 				os.Setenv("EXPIRATION", "43200")
+				os.Setenv("KEY_TTL_MIN", "600")
+				os.Setenv("KEY_TTL_MAX", "259200")
 				os.Setenv("CLEANUP_INTERVAL", "1800")
 				os.Setenv("TIMEOUT", "30")
+				os.Setenv("MAX_RETRIES", "5")
 			},
 			expectedError: false,
 		},
+		{
+			name: "Negative max retries",
+			envSetup: func() {
+				os.Setenv("ALLOWED_USERS", "user@example.com")
+				os.Setenv("OPENAI_MANAGEMENT_KEY", "test-key")
+				os.Setenv("CLIENT_ID", "test-client-id")
+				os.Setenv("CLIENT_SECRET", "test-client-secret")
+				os.Setenv("REDIRECT_URI", "http://localhost:8080/callback")
+				os.Setenv("MAX_RETRIES", "-1")
+			},
+			expectedError: true,
+		},
+		{
+			name: "Negative rate limit",
+			envSetup: func() {
+				os.Setenv("ALLOWED_USERS", "user@example.com")
+				os.Setenv("OPENAI_MANAGEMENT_KEY", "test-key")
+				os.Setenv("CLIENT_ID", "test-client-id")
+				os.Setenv("CLIENT_SECRET", "test-client-secret")
+				os.Setenv("REDIRECT_URI", "http://localhost:8080/callback")
+				os.Setenv("OPENAI_RATE_LIMIT", "-1")
+			},
+			expectedError: true,
+		},
+		{
+			name: "Zero rate burst",
+			envSetup: func() {
+				os.Setenv("ALLOWED_USERS", "user@example.com")
+				os.Setenv("OPENAI_MANAGEMENT_KEY", "test-key")
+				os.Setenv("CLIENT_ID", "test-client-id")
+				os.Setenv("CLIENT_SECRET", "test-client-secret")
+				os.Setenv("REDIRECT_URI", "http://localhost:8080/callback")
+				os.Setenv("OPENAI_RATE_BURST", "0")
+			},
+			expectedError: true,
+		},
+		{
+			name: "Negative project cache TTL",
+			envSetup: func() {
+				os.Setenv("ALLOWED_USERS", "user@example.com")
+				os.Setenv("OPENAI_MANAGEMENT_KEY", "test-key")
+				os.Setenv("CLIENT_ID", "test-client-id")
+				os.Setenv("CLIENT_SECRET", "test-client-secret")
+				os.Setenv("REDIRECT_URI", "http://localhost:8080/callback")
+				os.Setenv("PROJECT_CACHE_TTL", "-1")
+			},
+			expectedError: true,
+		},
+		{
+			name: "Negative breaker threshold",
+			envSetup: func() {
+				os.Setenv("ALLOWED_USERS", "user@example.com")
+				os.Setenv("OPENAI_MANAGEMENT_KEY", "test-key")
+				os.Setenv("CLIENT_ID", "test-client-id")
+				os.Setenv("CLIENT_SECRET", "test-client-secret")
+				os.Setenv("REDIRECT_URI", "http://localhost:8080/callback")
+				os.Setenv("BREAKER_FAILURE_THRESHOLD", "-1")
+			},
+			expectedError: true,
+		},
+		{
+			name: "Relative metrics path",
+			envSetup: func() {
+				os.Setenv("ALLOWED_USERS", "user@example.com")
+				os.Setenv("OPENAI_MANAGEMENT_KEY", "test-key")
+				os.Setenv("CLIENT_ID", "test-client-id")
+				os.Setenv("CLIENT_SECRET", "test-client-secret")
+				os.Setenv("REDIRECT_URI", "http://localhost:8080/callback")
+				os.Setenv("METRICS_PATH", "metrics")
+			},
+			expectedError: true,
+		},
+		{
+			name: "Short expiration without key TTL limits",
+			envSetup: func() {
+				os.Setenv("ALLOWED_USERS", "user@example.com")
+				os.Setenv("OPENAI_MANAGEMENT_KEY", "test-key")
+				os.Setenv("CLIENT_ID", "test-client-id")
+				os.Setenv("CLIENT_SECRET", "test-client-secret")
+				os.Setenv("REDIRECT_URI", "http://localhost:8080/callback")
+				os.Setenv("EXPIRATION", "60")
+			},
+			expectedError: false,
+		},
+		{
+			name: "Minimum key TTL above expiration",
+			envSetup: func() {
+				os.Setenv("ALLOWED_USERS", "user@example.com")
+				os.Setenv("OPENAI_MANAGEMENT_KEY", "test-key")
+				os.Setenv("CLIENT_ID", "test-client-id")
+				os.Setenv("CLIENT_SECRET", "test-client-secret")
+				os.Setenv("REDIRECT_URI", "http://localhost:8080/callback")
+				os.Setenv("KEY_TTL_MIN", "172800")
+			},
+			expectedError: true,
+		},
+		{
+			name: "Maximum key TTL below expiration",
+			envSetup: func() {
+				os.Setenv("ALLOWED_USERS", "user@example.com")
+				os.Setenv("OPENAI_MANAGEMENT_KEY", "test-key")
+				os.Setenv("CLIENT_ID", "test-client-id")
+				os.Setenv("CLIENT_SECRET", "test-client-secret")
+				os.Setenv("REDIRECT_URI", "http://localhost:8080/callback")
+				os.Setenv("KEY_TTL_MAX", "3600")
+			},
+			expectedError: true,
+		},
+		{
+			name: "Unknown key store",
+			envSetup: func() {
+				os.Setenv("ALLOWED_USERS", "user@example.com")
+				os.Setenv("OPENAI_MANAGEMENT_KEY", "test-key")
+				os.Setenv("CLIENT_ID", "test-client-id")
+				os.Setenv("CLIENT_SECRET", "test-client-secret")
+				os.Setenv("REDIRECT_URI", "http://localhost:8080/callback")
+				os.Setenv("KEY_STORE", "redis")
+			},
+			expectedError: true,
+		},
+		{
+			name: "File key store without path",
+			envSetup: func() {
+				os.Setenv("ALLOWED_USERS", "user@example.com")
+				os.Setenv("OPENAI_MANAGEMENT_KEY", "test-key")
+				os.Setenv("CLIENT_ID", "test-client-id")
+				os.Setenv("CLIENT_SECRET", "test-client-secret")
+				os.Setenv("REDIRECT_URI", "http://localhost:8080/callback")
+				os.Setenv("KEY_STORE", "file")
+			},
+			expectedError: true,
+		},
+		{
+			name: "Client certificate without key",
+			envSetup: func() {
+				os.Setenv("ALLOWED_USERS", "user@example.com")
+				os.Setenv("OPENAI_MANAGEMENT_KEY", "test-key")
+				os.Setenv("CLIENT_ID", "test-client-id")
+				os.Setenv("CLIENT_SECRET", "test-client-secret")
+				os.Setenv("REDIRECT_URI", "http://localhost:8080/callback")
+				os.Setenv("OUTBOUND_CLIENT_CERT_FILE", "/etc/ssl/client.pem")
+			},
+			expectedError: true,
+		},
+		{
+			name: "Negative connection limit",
+			envSetup: func() {
+				os.Setenv("ALLOWED_USERS", "user@example.com")
+				os.Setenv("OPENAI_MANAGEMENT_KEY", "test-key")
+				os.Setenv("CLIENT_ID", "test-client-id")
+				os.Setenv("CLIENT_SECRET", "test-client-secret")
+				os.Setenv("REDIRECT_URI", "http://localhost:8080/callback")
+				os.Setenv("OUTBOUND_MAX_CONNS_PER_HOST", "-1")
+			},
+			expectedError: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -167,8 +350,20 @@ func TestNewConfig(t *testing.T) {
 			os.Unsetenv("DEFAULT_PROJECT_NAME")
 			os.Unsetenv("PORT")
 			os.Unsetenv("EXPIRATION")
+			os.Unsetenv("KEY_TTL_MIN")
+			os.Unsetenv("KEY_TTL_MAX")
 			os.Unsetenv("CLEANUP_INTERVAL")
 			os.Unsetenv("TIMEOUT")
+			os.Unsetenv("MAX_RETRIES")
+			os.Unsetenv("OPENAI_RATE_LIMIT")
+			os.Unsetenv("OPENAI_RATE_BURST")
+			os.Unsetenv("PROJECT_CACHE_TTL")
+			os.Unsetenv("BREAKER_FAILURE_THRESHOLD")
+			os.Unsetenv("METRICS_PATH")
+			os.Unsetenv("KEY_STORE")
+			os.Unsetenv("KEY_STORE_PATH")
+			os.Unsetenv("OUTBOUND_CLIENT_CERT_FILE")
+			os.Unsetenv("OUTBOUND_MAX_CONNS_PER_HOST")
 
 			// Set up test environment
 			tt.envSetup()
@@ -204,19 +399,41 @@ func TestNewConfig(t *testing.T) {
 func TestConfigGetters(t *testing.T) {
 	// Create a test config
 	cfg := &Config{
-		AllowedUsers:         "user1@example.com,user2@example.com",
-		AllowedDomains:       "example.com,test.com",
-		OpenAIManagementKey:  "test-key",
-		ClientID:             "test-client-id",
-		ClientSecret:         "test-client-secret",
-		RedirectURI:          "http://localhost:8080/callback",
-		DefaultProjectName:   "test-project",
-		Port:                 "9000",
-		Expiration:           43200,
-		CleanupInterval:      1800,
-		Timeout:              30,
-		GoogleTokenIssuerURL: "https://accounts.google.com",
-		GoogleTokenJwksURL:   "https://www.googleapis.com/oauth2/v3/certs",
+		AllowedUsers:                "user1@example.com,user2@example.com",
+		AllowedDomains:              "example.com,test.com",
+		OpenAIManagementKey:         "test-key",
+		OpenAIBaseURL:               "http://localhost:9090/v1/organization",
+		ClientID:                    "test-client-id",
+		ClientSecret:                "test-client-secret",
+		RedirectURI:                 "http://localhost:8080/callback",
+		DefaultProjectName:          "test-project",
+		Port:                        "9000",
+		Expiration:                  43200,
+		KeyTTLMin:                   600,
+		KeyTTLMax:                   259200,
+		CleanupInterval:             1800,
+		CleanupDryRun:               true,
+		Timeout:                     30,
+		MaxRetries:                  5,
+		OpenAIRateLimit:             2.5,
+		OpenAIRateBurst:             4,
+		ProjectCacheTTL:             60,
+		OpenAIStrictDecoding:        true,
+		BreakerFailureThreshold:     3,
+		BreakerOpenTimeout:          15,
+		MetricsPath:                 "/debug/vars",
+		KeyStore:                    "sqlite",
+		KeyStorePath:                "/var/lib/openaikeyserver/keys.db",
+		OutboundProxyURL:            "http://proxy.example.com:3128",
+		OutboundCAFiles:             "/etc/ssl/corp-ca.pem,/etc/ssl/extra-ca.pem",
+		OutboundClientCertFile:      "/etc/ssl/client.pem",
+		OutboundClientKeyFile:       "/etc/ssl/client-key.pem",
+		OutboundMaxIdleConns:        50,
+		OutboundMaxIdleConnsPerHost: 5,
+		OutboundMaxConnsPerHost:     20,
+		OutboundIdleConnTimeout:     30,
+		GoogleTokenIssuerURL:        "https://accounts.google.com",
+		GoogleTokenJwksURL:          "https://www.googleapis.com/oauth2/v3/certs",
 	}
 
 	// Test GetAllowedUsers
@@ -236,6 +453,11 @@ func TestConfigGetters(t *testing.T) {
 		t.Errorf("GetOpenAIManagementKey() = %v, want test-key", key)
 	}
 
+	// Test GetOpenAIBaseURL
+	if url := cfg.GetOpenAIBaseURL(); url != "http://localhost:9090/v1/organization" {
+		t.Errorf("GetOpenAIBaseURL() = %v, want http://localhost:9090/v1/organization", url)
+	}
+
 	// Test GetClientID
 	if id := cfg.GetClientID(); id != "test-client-id" {
 		t.Errorf("GetClientID() = %v, want test-client-id", id)
@@ -266,16 +488,114 @@ func TestConfigGetters(t *testing.T) {
 		t.Errorf("GetExpiration() = %v, want %v", exp, 43200*time.Second)
 	}
 
+	// Test GetKeyTTLMin and GetKeyTTLMax
+	if ttl := cfg.GetKeyTTLMin(); ttl != 600*time.Second {
+		t.Errorf("GetKeyTTLMin() = %v, want %v", ttl, 600*time.Second)
+	}
+	if ttl := cfg.GetKeyTTLMax(); ttl != 259200*time.Second {
+		t.Errorf("GetKeyTTLMax() = %v, want %v", ttl, 259200*time.Second)
+	}
+	if ttl := (&Config{Expiration: 60}).GetKeyTTLMin(); ttl != 0 {
+		t.Errorf("GetKeyTTLMin() without a minimum = %v, want 0", ttl)
+	}
+	if ttl := (&Config{Expiration: 43200}).GetKeyTTLMax(); ttl != 43200*time.Second {
+		t.Errorf("GetKeyTTLMax() without a maximum = %v, want %v", ttl, 43200*time.Second)
+	}
+
 	// Test GetCleanupInterval
 	if interval := cfg.GetCleanupInterval(); interval != 1800*time.Second {
 		t.Errorf("GetCleanupInterval() = %v, want %v", interval, 1800*time.Second)
 	}
 
+	// Test GetCleanupDryRun
+	if !cfg.GetCleanupDryRun() {
+		t.Error("GetCleanupDryRun() = false, want true")
+	}
+
 	// Test GetTimeout
 	if timeout := cfg.GetTimeout(); timeout != 30*time.Second {
 		t.Errorf("GetTimeout() = %v, want %v", timeout, 30*time.Second)
 	}
 
+	// Test GetMaxRetries
+	if retries := cfg.GetMaxRetries(); retries != 5 {
+		t.Errorf("GetMaxRetries() = %v, want 5", retries)
+	}
+
+	// Test GetOpenAIRateLimit
+	if rate := cfg.GetOpenAIRateLimit(); rate != 2.5 {
+		t.Errorf("GetOpenAIRateLimit() = %v, want 2.5", rate)
+	}
+
+	// Test GetOpenAIRateBurst
+	if burst := cfg.GetOpenAIRateBurst(); burst != 4 {
+		t.Errorf("GetOpenAIRateBurst() = %v, want 4", burst)
+	}
+
+	// Test GetProjectCacheTTL
+	if ttl := cfg.GetProjectCacheTTL(); ttl != 60*time.Second {
+		t.Errorf("GetProjectCacheTTL() = %v, want %v", ttl, 60*time.Second)
+	}
+
+	// Test GetOpenAIStrictDecoding
+	if !cfg.GetOpenAIStrictDecoding() {
+		t.Errorf("GetOpenAIStrictDecoding() = false, want true")
+	}
+
+	// Test circuit breaker getters
+	if threshold := cfg.GetBreakerFailureThreshold(); threshold != 3 {
+		t.Errorf("GetBreakerFailureThreshold() = %v, want 3", threshold)
+	}
+	if timeout := cfg.GetBreakerOpenTimeout(); timeout != 15*time.Second {
+		t.Errorf("GetBreakerOpenTimeout() = %v, want %v", timeout, 15*time.Second)
+	}
+
+	// Test GetMetricsPath
+	if path := cfg.GetMetricsPath(); path != "/debug/vars" {
+		t.Errorf("GetMetricsPath() = %v, want /debug/vars", path)
+	}
+
+	// Test key store getters
+	if keyStore := cfg.GetKeyStore(); keyStore != "sqlite" {
+		t.Errorf("GetKeyStore() = %v, want sqlite", keyStore)
+	}
+	if path := cfg.GetKeyStorePath(); path != "/var/lib/openaikeyserver/keys.db" {
+		t.Errorf("GetKeyStorePath() = %v, want /var/lib/openaikeyserver/keys.db", path)
+	}
+
+	// Test GetOutboundProxyURL
+	if proxy := cfg.GetOutboundProxyURL(); proxy != "http://proxy.example.com:3128" {
+		t.Errorf("GetOutboundProxyURL() = %v, want http://proxy.example.com:3128", proxy)
+	}
+
+	// Test GetOutboundCAFiles
+	caFiles := cfg.GetOutboundCAFiles()
+	if len(*caFiles) != 2 || (*caFiles)[0] != "/etc/ssl/corp-ca.pem" || (*caFiles)[1] != "/etc/ssl/extra-ca.pem" {
+		t.Errorf("GetOutboundCAFiles() = %v, want [/etc/ssl/corp-ca.pem /etc/ssl/extra-ca.pem]", *caFiles)
+	}
+
+	// Test GetOutboundClientCertFile and GetOutboundClientKeyFile
+	if cert := cfg.GetOutboundClientCertFile(); cert != "/etc/ssl/client.pem" {
+		t.Errorf("GetOutboundClientCertFile() = %v, want /etc/ssl/client.pem", cert)
+	}
+	if key := cfg.GetOutboundClientKeyFile(); key != "/etc/ssl/client-key.pem" {
+		t.Errorf("GetOutboundClientKeyFile() = %v, want /etc/ssl/client-key.pem", key)
+	}
+
+	// Test connection pool getters
+	if n := cfg.GetOutboundMaxIdleConns(); n != 50 {
+		t.Errorf("GetOutboundMaxIdleConns() = %v, want 50", n)
+	}
+	if n := cfg.GetOutboundMaxIdleConnsPerHost(); n != 5 {
+		t.Errorf("GetOutboundMaxIdleConnsPerHost() = %v, want 5", n)
+	}
+	if n := cfg.GetOutboundMaxConnsPerHost(); n != 20 {
+		t.Errorf("GetOutboundMaxConnsPerHost() = %v, want 20", n)
+	}
+	if timeout := cfg.GetOutboundIdleConnTimeout(); timeout != 30*time.Second {
+		t.Errorf("GetOutboundIdleConnTimeout() = %v, want %v", timeout, 30*time.Second)
+	}
+
 	// Test GetGoogleTokenIssuerURL
 	if url := cfg.GetGoogleTokenIssuerURL(); url != "https://accounts.google.com" {
 		t.Errorf("GetGoogleTokenIssuerURL() = %v, want https://accounts.google.com", url)
@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/hi120ki/monorepo/projects/openaikeyserver/client"
 	"golang.org/x/oauth2"
 )
 
@@ -51,8 +52,12 @@ func (h *Handler) HandleOAuthCallback(w http.ResponseWriter, r *http.Request) {
 		HttpOnly: true,
 	})
 
+	// Read and remove the requested key lifetime
+	ttl := requestedTTL(r)
+	clearTTLCookie(w)
+
 	// Exchange code for token
-	token, err := h.oauth2Config.Exchange(ctx, code)
+	token, err := h.oauth2Config.Exchange(h.oidc.ClientContext(ctx), code)
 	if err != nil {
 		var retrieveErr *oauth2.RetrieveError
 		if errors.As(err, &retrieveErr) {
@@ -79,9 +84,14 @@ func (h *Handler) HandleOAuthCallback(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Generate API key
-	key, expiration, err := h.management.CreateAPIKey(ctx, projectName, serviceAccountName)
+	key, expiration, err := h.management.CreateAPIKey(ctx, projectName, serviceAccountName, ttl)
+	if errors.Is(err, client.ErrCircuitOpen) {
+		h.handleUnavailable(w, r, err)
+		return
+	}
 	if err != nil {
-		h.handleError(w, r, err, http.StatusInternalServerError, "Failed to create API key")
+		status, msg := apiErrorResponse(err, "Failed to create API key")
+		h.handleError(w, r, err, status, msg)
 		return
 	}
 
@@ -3,9 +3,15 @@ package handler
 import (
 	"crypto/rand"
 	"encoding/base64"
+	"errors"
+	"fmt"
 	"log/slog"
+	"math"
 	"net/http"
+	"strconv"
+	"time"
 
+	"github.com/hi120ki/monorepo/projects/openaikeyserver/client"
 	"github.com/hi120ki/monorepo/projects/openaikeyserver/management"
 	"github.com/hi120ki/monorepo/projects/openaikeyserver/oidc"
 	"golang.org/x/oauth2"
@@ -46,6 +52,56 @@ func (h *Handler) handleError(w http.ResponseWriter, r *http.Request, err error,
 	http.Error(w, msg, status)
 }
 
+// apiErrorResponse maps OpenAI API errors to a status code and a message that is safe to show to users.
+func apiErrorResponse(err error, fallback string) (int, string) {
+	switch {
+	case errors.Is(err, client.ErrCircuitOpen):
+		return http.StatusServiceUnavailable, "The OpenAI API is currently unavailable. Please try again in a few minutes."
+	case errors.Is(err, management.ErrProjectArchived):
+		return http.StatusInternalServerError, "The OpenAI project for API keys has been archived. Please contact the administrator."
+	case errors.Is(err, client.ErrUnauthorized):
+		return http.StatusBadGateway, "The server's OpenAI management key was rejected. Please contact the administrator."
+	case errors.Is(err, client.ErrQuotaExceeded):
+		return http.StatusServiceUnavailable, "The OpenAI organization has exceeded its quota. Please contact the administrator."
+	case errors.Is(err, client.ErrRateLimited):
+		return http.StatusServiceUnavailable, "OpenAI is rate limiting requests. Please try again in a few minutes."
+	}
+	return http.StatusInternalServerError, fallback
+}
+
+// handleUnavailable renders a page telling the user that the OpenAI API is unavailable.
+func (h *Handler) handleUnavailable(w http.ResponseWriter, r *http.Request, err error) {
+	slog.Warn("openai api unavailable", "error", err, "path", r.URL.Path, "method", r.Method)
+
+	var openErr *client.CircuitOpenError
+	if errors.As(err, &openErr) && openErr.RetryAfter > 0 {
+		w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(openErr.RetryAfter.Seconds()))))
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(http.StatusServiceUnavailable)
+	if _, err := fmt.Fprint(w, unavailableHTML); err != nil {
+		slog.Error("write response", "error", err)
+	}
+}
+
+const unavailableHTML = `
+<!doctype html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <meta name="viewport" content="width=device-width, initial-scale=1">
+  <title>OpenAI API Unavailable</title>
+  <link href="https://cdn.jsdelivr.net/npm/bootstrap@5.3.3/dist/css/bootstrap.min.css" rel="stylesheet">
+</head>
+<body class="bg-light">
+  <div class="container py-5">
+    <h1 class="mb-4">OpenAI is temporarily unavailable</h1>
+    <p class="text-muted mb-3">We could not reach the OpenAI API to issue your key. Please try again in a few minutes.</p>
+    <a class="btn btn-primary" href="/">Try again</a>
+  </div>
+</body>
+</html>`
+
 // generateStateOauthCookie creates a secure random state token and stores it in a cookie.
 func (h *Handler) generateStateOauthCookie(w http.ResponseWriter, r *http.Request) (string, error) {
 	b := make([]byte, 32)
@@ -66,3 +122,59 @@ func (h *Handler) generateStateOauthCookie(w http.ResponseWriter, r *http.Reques
 
 	return state, nil
 }
+
+// ttlCookieName is the cookie that carries the requested key lifetime through the OAuth2 flow.
+const ttlCookieName = "oauthttl"
+
+// parseTTL parses a requested key lifetime. An empty value requests the default lifetime and returns zero.
+func parseTTL(value string) (time.Duration, error) {
+	if value == "" {
+		return 0, nil
+	}
+	ttl, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("parse ttl: %w", err)
+	}
+	if ttl <= 0 {
+		return 0, fmt.Errorf("parse ttl: %s is not positive", value)
+	}
+	return ttl, nil
+}
+
+// setTTLCookie stores the requested key lifetime for the OAuth2 callback.
+func setTTLCookie(w http.ResponseWriter, r *http.Request, ttl time.Duration) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     ttlCookieName,
+		Value:    ttl.String(),
+		Path:     "/",
+		MaxAge:   600,
+		HttpOnly: true,
+		Secure:   r.TLS != nil, // Set Secure flag if using HTTPS
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
+// clearTTLCookie removes the requested key lifetime cookie.
+func clearTTLCookie(w http.ResponseWriter) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     ttlCookieName,
+		Value:    "",
+		Path:     "/",
+		MaxAge:   -1,
+		HttpOnly: true,
+	})
+}
+
+// requestedTTL returns the key lifetime requested before the OAuth2 flow, or zero for the default.
+// The lifetime is clamped by management, so a tampered cookie cannot exceed the configured limits.
+func requestedTTL(r *http.Request) time.Duration {
+	cookie, err := r.Cookie(ttlCookieName)
+	if err != nil {
+		return 0
+	}
+	ttl, err := parseTTL(cookie.Value)
+	if err != nil {
+		return 0
+	}
+	return ttl
+}
@@ -2,11 +2,14 @@ package handler
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 	"time"
 
+	"github.com/hi120ki/monorepo/projects/openaikeyserver/client"
 	"github.com/hi120ki/monorepo/projects/openaikeyserver/management"
 	"github.com/hi120ki/monorepo/projects/openaikeyserver/oidc"
 	"golang.org/x/oauth2"
@@ -26,25 +29,25 @@ func (m *MockHTTPClient) Do(req *http.Request) (*http.Response, error) {
 
 // MockManagement is a mock implementation of the management.Manager interface
 type MockManagement struct {
-	CreateAPIKeyFunc  func(ctx context.Context, projectName, serviceAccountName string) (string, *time.Time, error)
-	CleanupAPIKeyFunc func(ctx context.Context, projectName string) error
+	CreateAPIKeyFunc  func(ctx context.Context, projectName, serviceAccountName string, ttl time.Duration) (string, *time.Time, error)
+	CleanupAPIKeyFunc func(ctx context.Context, projectName string, dryRun bool) (*management.CleanupReport, error)
 }
 
 // Ensure MockManagement implements management.Manager
 var _ management.Manager = (*MockManagement)(nil)
 
-func (m *MockManagement) CreateAPIKey(ctx context.Context, projectName, serviceAccountName string) (string, *time.Time, error) {
+func (m *MockManagement) CreateAPIKey(ctx context.Context, projectName, serviceAccountName string, ttl time.Duration) (string, *time.Time, error) {
 	if m.CreateAPIKeyFunc != nil {
-		return m.CreateAPIKeyFunc(ctx, projectName, serviceAccountName)
+		return m.CreateAPIKeyFunc(ctx, projectName, serviceAccountName, ttl)
 	}
 	return "", nil, nil
 }
 
-func (m *MockManagement) CleanupAPIKey(ctx context.Context, projectName string) error {
+func (m *MockManagement) CleanupAPIKey(ctx context.Context, projectName string, dryRun bool) (*management.CleanupReport, error) {
 	if m.CleanupAPIKeyFunc != nil {
-		return m.CleanupAPIKeyFunc(ctx, projectName)
+		return m.CleanupAPIKeyFunc(ctx, projectName, dryRun)
 	}
-	return nil
+	return &management.CleanupReport{}, nil
 }
 
 func TestNewHandler(t *testing.T) {
@@ -106,6 +109,56 @@ func TestHandleError(t *testing.T) {
 	}
 }
 
+func TestAPIErrorResponse(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            error
+		expectedStatus int
+	}{
+		{name: "Circuit open", err: &client.CircuitOpenError{RetryAfter: time.Second}, expectedStatus: http.StatusServiceUnavailable},
+		{name: "Unauthorized", err: &client.APIError{StatusCode: 401}, expectedStatus: http.StatusBadGateway},
+		{name: "Quota exceeded", err: &client.APIError{StatusCode: 429, Code: "insufficient_quota"}, expectedStatus: http.StatusServiceUnavailable},
+		{name: "Rate limited", err: &client.APIError{StatusCode: 429}, expectedStatus: http.StatusServiceUnavailable},
+		{name: "Other error", err: errors.New("other error"), expectedStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, msg := apiErrorResponse(fmt.Errorf("create api key: %w", tt.err), "fallback")
+			if status != tt.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tt.expectedStatus, status)
+			}
+			if tt.expectedStatus == http.StatusInternalServerError && msg != "fallback" {
+				t.Errorf("Expected fallback message, got '%s'", msg)
+			}
+		})
+	}
+}
+
+func TestHandleUnavailable(t *testing.T) {
+	// Create handler
+	h := &Handler{}
+
+	// Create test request and response recorder
+	req := httptest.NewRequest("GET", "/oauth2/callback", nil)
+	w := httptest.NewRecorder()
+
+	// Test handleUnavailable
+	h.handleUnavailable(w, req, fmt.Errorf("create api key: %w", &client.CircuitOpenError{RetryAfter: 1500 * time.Millisecond}))
+
+	// Verify response
+	resp := w.Result()
+	if resp.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("Expected status code %d, got %d", http.StatusServiceUnavailable, resp.StatusCode)
+	}
+	if retryAfter := resp.Header.Get("Retry-After"); retryAfter != "2" {
+		t.Errorf("Expected Retry-After header to be '2', got '%s'", retryAfter)
+	}
+	if contentType := resp.Header.Get("Content-Type"); contentType != "text/html; charset=utf-8" {
+		t.Errorf("Expected HTML response, got '%s'", contentType)
+	}
+}
+
 func TestGenerateStateOauthCookie(t *testing.T) {
 	// Create handler
 	h := &Handler{}
@@ -200,14 +253,95 @@ func TestHandleRoot(t *testing.T) {
 	}
 }
 
+func TestHandleRoot_TTL(t *testing.T) {
+	// Create handler
+	h := &Handler{
+		oauth2Config: &oauth2.Config{
+			ClientID:    "test-client-id",
+			RedirectURL: "http://localhost:8080/callback",
+			Endpoint: oauth2.Endpoint{
+				AuthURL: "https://accounts.google.com/o/oauth2/v2/auth",
+			},
+		},
+	}
+
+	// Test HandleRoot with a requested lifetime
+	req := httptest.NewRequest("GET", "/?ttl=72h", nil)
+	w := httptest.NewRecorder()
+	h.HandleRoot(w, req)
+
+	// Verify the lifetime is remembered for the callback
+	resp := w.Result()
+	if resp.StatusCode != http.StatusFound {
+		t.Errorf("Expected status code %d, got %d", http.StatusFound, resp.StatusCode)
+	}
+	var ttlCookie *http.Cookie
+	for _, cookie := range resp.Cookies() {
+		if cookie.Name == ttlCookieName {
+			ttlCookie = cookie
+		}
+	}
+	if ttlCookie == nil {
+		t.Fatal("Expected a ttl cookie")
+	}
+	callbackReq := httptest.NewRequest("GET", "/callback", nil)
+	callbackReq.AddCookie(ttlCookie)
+	if ttl := requestedTTL(callbackReq); ttl != 72*time.Hour {
+		t.Errorf("Expected requested TTL %v, got %v", 72*time.Hour, ttl)
+	}
+
+	// Test HandleRoot with an invalid lifetime
+	req = httptest.NewRequest("GET", "/?ttl=forever", nil)
+	w = httptest.NewRecorder()
+	h.HandleRoot(w, req)
+
+	// Verify response
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status code %d, got %d", http.StatusBadRequest, w.Code)
+	}
+}
+
+func TestParseTTL(t *testing.T) {
+	tests := []struct {
+		value       string
+		expected    time.Duration
+		expectError bool
+	}{
+		{"", 0, false},
+		{"30m", 30 * time.Minute, false},
+		{"72h", 72 * time.Hour, false},
+		{"0s", 0, true},
+		{"-1h", 0, true},
+		{"3 days", 0, true},
+	}
+	for _, tt := range tests {
+		ttl, err := parseTTL(tt.value)
+		if tt.expectError {
+			if err == nil {
+				t.Errorf("parseTTL(%q): expected error, got nil", tt.value)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parseTTL(%q): unexpected error: %v", tt.value, err)
+		}
+		if ttl != tt.expected {
+			t.Errorf("parseTTL(%q) = %v, want %v", tt.value, ttl, tt.expected)
+		}
+	}
+}
+
 func TestHandleRevoke(t *testing.T) {
 	// Create mock management
 	mockManagement := &MockManagement{
-		CleanupAPIKeyFunc: func(ctx context.Context, projectName string) error {
+		CleanupAPIKeyFunc: func(ctx context.Context, projectName string, dryRun bool) (*management.CleanupReport, error) {
 			if projectName != "test-project" {
 				t.Errorf("Expected project name to be 'test-project', got '%s'", projectName)
 			}
-			return nil
+			if dryRun {
+				t.Error("Expected /revoke to delete expired keys, got a dry run")
+			}
+			return &management.CleanupReport{ProjectID: "proj_123"}, nil
 		},
 	}
 
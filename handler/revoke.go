@@ -10,8 +10,13 @@ func (h *Handler) HandleRevoke(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	// Trigger API key cleanup
-	if err := h.management.CleanupAPIKey(ctx, h.oidc.GetDefaultProjectName()); err != nil {
-		h.handleError(w, r, err, http.StatusInternalServerError, "Failed to cleanup API keys")
+	report, err := h.management.CleanupAPIKey(ctx, h.oidc.GetDefaultProjectName(), false)
+	if err != nil {
+		if report != nil {
+			slog.Warn("api key cleanup failed part way", "deleted", len(report.Entries))
+		}
+		status, msg := apiErrorResponse(err, "Failed to cleanup API keys")
+		h.handleError(w, r, err, status, msg)
 		return
 	}
 
@@ -21,5 +26,5 @@ func (h *Handler) HandleRevoke(w http.ResponseWriter, r *http.Request) {
 		h.handleError(w, r, err, http.StatusInternalServerError, "Failed to write response")
 		return
 	}
-	slog.Info("api key cleanup completed successfully")
+	slog.Info("api key cleanup completed successfully", "deleted", len(report.Entries))
 }
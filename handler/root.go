@@ -7,7 +7,15 @@ import (
 )
 
 // HandleRoot initiates the OAuth2 authentication flow by redirecting to the consent page.
+// An optional ttl query parameter, such as ?ttl=72h, requests the lifetime of the issued key.
 func (h *Handler) HandleRoot(w http.ResponseWriter, r *http.Request) {
+	// Validate the requested key lifetime
+	ttl, err := parseTTL(r.URL.Query().Get("ttl"))
+	if err != nil {
+		h.handleError(w, r, err, http.StatusBadRequest, "Invalid ttl parameter; use a duration such as 30m, 8h or 72h")
+		return
+	}
+
 	// Create and store state token in cookie
 	state, err := h.generateStateOauthCookie(w, r)
 	if err != nil {
@@ -15,6 +23,13 @@ func (h *Handler) HandleRoot(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Remember the requested key lifetime until the callback
+	if ttl > 0 {
+		setTTLCookie(w, r, ttl)
+	} else if _, err := r.Cookie(ttlCookieName); err == nil {
+		clearTTLCookie(w)
+	}
+
 	// Build OAuth2 consent page URL
 	url := h.oauth2Config.AuthCodeURL(state, oauth2.AccessTypeOffline)
 
@@ -0,0 +1,123 @@
+package management
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/hi120ki/monorepo/projects/openaikeyserver/client"
+	"github.com/hi120ki/monorepo/projects/openaikeyserver/openaitest"
+)
+
+func newE2EClient(srv *openaitest.Server) *client.Client {
+	c := client.NewClient(openaitest.APIKey, srv.Client())
+	c.BaseURL = srv.BaseURL()
+	c.Retry = client.RetryPolicy{MaxRetries: 2, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond}
+	return c
+}
+
+func TestE2E_CreateAndCleanupAPIKey(t *testing.T) {
+	srv := openaitest.NewServer()
+	defer srv.Close()
+	expiration := 24 * time.Hour
+	management := NewManagement(newE2EClient(srv), expiration, nil)
+	ctx := context.Background()
+
+	// Issuing a key creates the project on first use
+	key, expiresAt, err := management.CreateAPIKey(ctx, "test-project", "user@example.com", 0)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if key == "" || expiresAt == nil {
+		t.Fatalf("Expected API key and expiration, got '%s' and %v", key, expiresAt)
+	}
+	projects := srv.Projects()
+	if len(projects) != 1 || projects[0].Name != "test-project" {
+		t.Fatalf("Expected a single project named test-project, got %+v", projects)
+	}
+	projectID := projects[0].ID
+
+	// Seed more expired service accounts than fit in one page, and one created by hand
+	createdAt := time.Now().Add(-2 * expiration)
+	for i := 0; i < openaitest.DefaultPageSize+5; i++ {
+		srv.AddServiceAccount(projectID, keyName{Owner: "old@example.com", ExpiresAt: createdAt.Add(expiration)}.String(), createdAt)
+	}
+	manual := srv.AddServiceAccount(projectID, "production-workload", createdAt)
+
+	// Cleanup removes only the expired service accounts of this server
+	if _, err := management.CleanupAPIKey(ctx, "test-project", false); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	remaining := srv.ServiceAccounts(projectID)
+	if len(remaining) != 2 || remaining[1].ID != manual.ID {
+		t.Fatalf("Expected the fresh and the hand-made service account to remain, got %+v", remaining)
+	}
+	if name, err := parseKeyName(remaining[0].Name); err != nil || name.Owner != "user@example.com" || !name.ExpiresAt.Equal(*expiresAt) {
+		t.Errorf("Expected the service account name to encode the owner and expiry, got %q", remaining[0].Name)
+	}
+}
+
+func TestE2E_CleanupWithoutStore(t *testing.T) {
+	srv := openaitest.NewServer()
+	defer srv.Close()
+	expiration := 24 * time.Hour
+	project := srv.AddProject("test-project")
+	now := time.Now()
+
+	// Service accounts issued by another replica, so this server has no record of them
+	short := srv.AddServiceAccount(project.ID, keyName{Owner: "short@example.com", ExpiresAt: now.Add(-1 * time.Minute)}.String(), now.Add(-1*time.Hour))
+	long := srv.AddServiceAccount(project.ID, keyName{Owner: "long@example.com", ExpiresAt: now.Add(expiration)}.String(), now.Add(-2*expiration))
+
+	// Cleanup follows the expiry encoded in each name
+	management := NewManagement(newE2EClient(srv), expiration, nil)
+	if _, err := management.CleanupAPIKey(context.Background(), "test-project", false); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	remaining := srv.ServiceAccounts(project.ID)
+	if len(remaining) != 1 || remaining[0].ID != long.ID {
+		t.Errorf("Expected only %s to remain after deleting %s, got %+v", long.ID, short.ID, remaining)
+	}
+}
+
+func TestE2E_ArchivedProject(t *testing.T) {
+	srv := openaitest.NewServer()
+	defer srv.Close()
+	project := srv.AddProject("test-project")
+	srv.ArchiveProject(project.ID)
+	management := NewManagement(newE2EClient(srv), time.Hour, nil)
+
+	// Issuance refuses the archived project instead of creating a duplicate
+	_, _, err := management.CreateAPIKey(context.Background(), "test-project", "user@example.com", 0)
+	if !errors.Is(err, ErrProjectArchived) {
+		t.Errorf("Expected error to be %v, got %v", ErrProjectArchived, err)
+	}
+	if n := len(srv.Projects()); n != 1 {
+		t.Errorf("Expected 1 project, got %d", n)
+	}
+}
+
+func TestE2E_TransientFailures(t *testing.T) {
+	srv := openaitest.NewServer()
+	defer srv.Close()
+	project := srv.AddProject("test-project")
+	srv.AddServiceAccount(project.ID, keyName{Owner: "old@example.com", ExpiresAt: time.Now().Add(-1 * time.Hour)}.String(), time.Now().Add(-2*time.Hour))
+	management := NewManagement(newE2EClient(srv), time.Hour, nil)
+
+	// Listing and deletion recover from one failure each
+	srv.InjectFault(openaitest.Fault{Method: http.MethodGet, Path: "/projects/*/service_accounts", Status: http.StatusBadGateway, Times: 1})
+	srv.InjectFault(openaitest.Fault{Method: http.MethodDelete, Path: "/projects/*/service_accounts/*", Status: http.StatusTooManyRequests, Times: 1})
+	if _, err := management.CleanupAPIKey(context.Background(), "test-project", false); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if n := len(srv.ServiceAccounts(project.ID)); n != 0 {
+		t.Errorf("Expected 0 service accounts, got %d", n)
+	}
+
+	// Service account creation is not retried
+	srv.InjectFault(openaitest.Fault{Method: http.MethodPost, Path: "/projects/*/service_accounts", Status: http.StatusInternalServerError, Times: 1})
+	if _, _, err := management.CreateAPIKey(context.Background(), "test-project", "user@example.com", 0); err == nil {
+		t.Error("Expected error, got nil")
+	}
+}
@@ -2,70 +2,291 @@ package management
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log/slog"
 	"time"
 
 	"github.com/hi120ki/monorepo/projects/openaikeyserver/client"
+	"github.com/hi120ki/monorepo/projects/openaikeyserver/store"
 )
 
+// ErrProjectArchived is returned when the project used for API keys has been archived.
+var ErrProjectArchived = errors.New("project is archived")
+
+// ErrKeyNotRecorded is returned when the inventory has no record of a service account.
+var ErrKeyNotRecorded = errors.New("api key not recorded")
+
 // Manager defines the interface for API key management operations.
 type Manager interface {
-	CreateAPIKey(ctx context.Context, projectName, serviceAccountName string) (string, *time.Time, error)
-	CleanupAPIKey(ctx context.Context, projectName string) error
+	CreateAPIKey(ctx context.Context, projectName, serviceAccountName string, ttl time.Duration) (string, *time.Time, error)
+	CleanupAPIKey(ctx context.Context, projectName string, dryRun bool) (*CleanupReport, error)
 }
 
 // Management implements the Manager interface and handles API key operations.
 type Management struct {
 	client     client.APIClient // Client for API operations
-	expiration time.Duration    // Expiration duration for API keys
+	expiration time.Duration    // Default lifetime of API keys
+	minTTL     time.Duration    // Shortest lifetime a user may request
+	maxTTL     time.Duration    // Longest lifetime a user may request
+	store      store.Store      // Inventory of issued keys
+	legacy     legacyOwners     // Owners of keys issued before names carried the expiry
 }
 
-func NewManagement(client client.APIClient, expiration time.Duration) *Management {
+// NewManagement creates a Management that records issued keys in keyStore, or in memory if keyStore is nil.
+func NewManagement(client client.APIClient, expiration time.Duration, keyStore store.Store) *Management {
+	if keyStore == nil {
+		keyStore = store.NewMemoryStore()
+	}
 	return &Management{
 		client:     client,
 		expiration: expiration,
+		minTTL:     expiration,
+		maxTTL:     expiration,
+		store:      keyStore,
 	}
 }
 
-func (m *Management) CreateAPIKey(ctx context.Context, projectName, serviceAccountName string) (string, *time.Time, error) {
+// SetTTLLimits sets the range that requested key lifetimes are clamped to. By default only the
+// default expiration is allowed.
+func (m *Management) SetTTLLimits(minTTL, maxTTL time.Duration) {
+	m.minTTL = minTTL
+	m.maxTTL = maxTTL
+}
+
+// SetLegacyOwners sets the users and domains whose keys may predate names that carry the expiry.
+// Service accounts named with such a bare email expire after the default expiration.
+func (m *Management) SetLegacyOwners(allowedUsers, allowedDomains *[]string) {
+	m.legacy = legacyOwners{users: allowedUsers, domains: allowedDomains}
+}
+
+// keyTTL returns the lifetime of a key for which ttl was requested; zero requests the default.
+func (m *Management) keyTTL(ttl time.Duration) time.Duration {
+	if ttl <= 0 {
+		ttl = m.expiration
+	}
+	return min(max(ttl, m.minTTL), m.maxTTL)
+}
+
+// CreateAPIKey issues a key to serviceAccountName that expires after ttl, clamped to the configured limits.
+func (m *Management) CreateAPIKey(ctx context.Context, projectName, serviceAccountName string, ttl time.Duration) (string, *time.Time, error) {
 	project, find, err := m.client.GetProject(ctx, projectName)
 	if err != nil {
 		return "", nil, fmt.Errorf("get project: %w", err)
 	}
 	if !find {
 		project, err = m.client.CreateProject(ctx, projectName)
+		if errors.Is(err, client.ErrConflict) {
+			// Another replica created the project after our lookup.
+			project, find, err = m.client.GetProject(ctx, projectName)
+			if err == nil && !find {
+				err = fmt.Errorf("find project %s after conflict", projectName)
+			}
+		}
 		if err != nil {
 			return "", nil, fmt.Errorf("create project: %w", err)
 		}
 	}
-	serviceAccount, err := m.client.CreateServiceAccount(ctx, project.ID, serviceAccountName)
+	if project.IsArchived() {
+		return "", nil, fmt.Errorf("use project %s: %w", projectName, ErrProjectArchived)
+	}
+	// The name carries the expiry in whole seconds, so issue keys on a second boundary.
+	issuedAt := time.Now().Truncate(time.Second)
+	expirationTime := issuedAt.Add(m.keyTTL(ttl))
+	name := keyName{Owner: serviceAccountName, ExpiresAt: expirationTime}
+	serviceAccount, err := m.client.CreateServiceAccount(ctx, project.ID, name.String())
 	if err != nil {
 		return "", nil, fmt.Errorf("create service account: %w", err)
 	}
-	expirationTime := time.Now().Add(m.expiration)
+	err = m.store.Update(ctx, func(tx store.Tx) error {
+		return tx.Put(store.Key{
+			OwnerEmail:       serviceAccountName,
+			ProjectID:        project.ID,
+			ServiceAccountID: serviceAccount.ID,
+			KeyID:            serviceAccount.APIKey.ID,
+			Label:            serviceAccount.Name,
+			IssuedAt:         issuedAt,
+			ExpiresAt:        expirationTime,
+		})
+	})
+	if err != nil {
+		// A key missing from the inventory would never be accounted for, so do not hand it out.
+		if _, delErr := m.client.DeleteServiceAccount(ctx, project.ID, serviceAccount.ID); delErr != nil {
+			slog.Error("delete unrecorded service account", "project_id", project.ID, "service_account_id", serviceAccount.ID, "error", delErr)
+		}
+		return "", nil, fmt.Errorf("record api key: %w", err)
+	}
 	return serviceAccount.APIKey.Value, &expirationTime, nil
 }
 
-func (m *Management) CleanupAPIKey(ctx context.Context, projectName string) error {
+// ListAPIKeys returns every key recorded in the inventory, ordered by issue time.
+func (m *Management) ListAPIKeys(ctx context.Context) (*[]store.Key, error) {
+	var keys *[]store.Key
+	err := m.store.View(ctx, func(tx store.Tx) error {
+		var err error
+		keys, err = tx.List()
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list api keys: %w", err)
+	}
+	return keys, nil
+}
+
+// GetAPIKey looks up the service account backing a recorded key, with the key's ID and redacted value.
+// The project and key ID come from the inventory, so the lookup reads one service account and one key
+// instead of listing the project.
+func (m *Management) GetAPIKey(ctx context.Context, serviceAccountID string) (*client.ServiceAccount, error) {
+	var record *store.Key
+	err := m.store.View(ctx, func(tx store.Tx) error {
+		key, found, err := tx.Get(serviceAccountID)
+		if err != nil {
+			return err
+		}
+		if !found {
+			return ErrKeyNotRecorded
+		}
+		record = key
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("read api key record: %w", err)
+	}
+	serviceAccount, err := m.client.GetServiceAccount(ctx, record.ProjectID, record.ServiceAccountID, record.KeyID)
+	if err != nil {
+		return nil, fmt.Errorf("get service account: %w", err)
+	}
+	return serviceAccount, nil
+}
+
+// CleanupAPIKey deletes the service accounts whose keys have expired. Each key expires at the time
+// encoded in its service account name or, failing that, recorded in the inventory. Service accounts
+// named with the bare email of a legacy owner expire after the default expiration; any other service
+// account was not created by this server and is left alone. The report lists the deleted
+// service accounts; with dryRun, it lists the ones that would be deleted and nothing is changed.
+// When cleanup fails part way, the report lists what was deleted before the failure.
+func (m *Management) CleanupAPIKey(ctx context.Context, projectName string, dryRun bool) (*CleanupReport, error) {
+	// Cleanup must not compete with key issuance for the shared rate limit.
+	ctx = client.WithPriority(ctx, client.PriorityBackground)
 	project, find, err := m.client.GetProject(ctx, projectName)
 	if err != nil {
-		return fmt.Errorf("get project: %w", err)
+		return nil, fmt.Errorf("get project: %w", err)
 	}
 	if !find {
-		return fmt.Errorf("find project %s", projectName)
+		return nil, fmt.Errorf("find project %s", projectName)
 	}
-	serviceAccounts, err := m.client.ListServiceAccounts(ctx, project.ID)
+	report := &CleanupReport{ProjectID: project.ID, DryRun: dryRun}
+	if project.IsArchived() {
+		// Keys in an archived project can no longer be used, and the project cannot be modified.
+		slog.Info("skip cleanup of archived project", "project", projectName, "project_id", project.ID)
+		return report, nil
+	}
+	records, err := m.keyRecords(ctx, project.ID)
+	if err != nil {
+		return report, err
+	}
+	// Collect expired service accounts while pages arrive and delete afterwards, so that deletions
+	// do not invalidate the pagination cursor of the listing.
+	listedAt := time.Now()
+	var expired []CleanupEntry
+	existing := make(map[string]bool)
+	for serviceAccount, err := range m.client.ServiceAccounts(ctx, project.ID) {
+		if err != nil {
+			return report, fmt.Errorf("list service accounts: %w", err)
+		}
+		existing[serviceAccount.ID] = true
+		entry := CleanupEntry{
+			ServiceAccountID: serviceAccount.ID,
+			Age:              listedAt.Sub(time.Unix(serviceAccount.CreatedAt, 0)),
+		}
+		record, ok := records[serviceAccount.ID]
+		name, err := parseKeyName(serviceAccount.Name)
+		switch {
+		case err == nil:
+			entry.Owner, entry.ExpiresAt, entry.Reason = name.Owner, name.ExpiresAt, CleanupReasonNameExpiry
+		case ok:
+			entry.Owner, entry.ExpiresAt, entry.Reason = record.OwnerEmail, record.ExpiresAt, CleanupReasonRecordedExpiry
+		case m.legacy.owns(serviceAccount.Name):
+			entry.Owner, entry.ExpiresAt, entry.Reason = serviceAccount.Name, time.Unix(serviceAccount.CreatedAt, 0).Add(m.expiration), CleanupReasonLegacyExpiry
+		default:
+			// The project may be shared with service accounts created by hand or by other tooling.
+			slog.Info("skip cleanup of service account not created by this server", "project_id", project.ID, "service_account_id", serviceAccount.ID, "name", serviceAccount.Name, "reason", err)
+			continue
+		}
+		if entry.ExpiresAt.Before(listedAt) {
+			expired = append(expired, entry)
+		}
+	}
+	if dryRun {
+		report.Entries = expired
+		return report, nil
+	}
+	for _, entry := range expired {
+		// Never hold a store transaction across an API call: retries may wait out a long Retry-After
+		// while key issuance needs the store.
+		_, err := m.client.DeleteServiceAccount(ctx, project.ID, entry.ServiceAccountID)
+		if err != nil && !errors.Is(err, client.ErrNotFound) {
+			return report, fmt.Errorf("delete service account: %w", err)
+		}
+		delete(existing, entry.ServiceAccountID)
+		report.Entries = append(report.Entries, entry)
+		err = m.store.Update(ctx, func(tx store.Tx) error {
+			return tx.Delete(entry.ServiceAccountID)
+		})
+		if err != nil {
+			return report, fmt.Errorf("forget api key: %w", err)
+		}
+	}
+	if err := m.pruneAPIKeys(ctx, project.ID, existing, listedAt); err != nil {
+		return report, err
+	}
+	return report, nil
+}
+
+// keyRecords returns the inventory records of the project, indexed by service account ID.
+func (m *Management) keyRecords(ctx context.Context, projectID string) (map[string]store.Key, error) {
+	records := make(map[string]store.Key)
+	err := m.store.View(ctx, func(tx store.Tx) error {
+		keys, err := tx.List()
+		if err != nil {
+			return err
+		}
+		for _, key := range *keys {
+			if key.ProjectID == projectID {
+				records[key.ServiceAccountID] = key
+			}
+		}
+		return nil
+	})
 	if err != nil {
-		return fmt.Errorf("list service accounts: %w", err)
-	}
-	for _, serviceAccount := range *serviceAccounts {
-		createdAt := time.Unix(serviceAccount.CreatedAt, 0)
-		cutoff := time.Now().Add(-1 * m.expiration)
-		if createdAt.Before(cutoff) {
-			if _, err := m.client.DeleteServiceAccount(ctx, project.ID, serviceAccount.ID); err != nil {
-				return fmt.Errorf("delete service account: %w", err)
+		return nil, fmt.Errorf("read api key records: %w", err)
+	}
+	return records, nil
+}
+
+// pruneAPIKeys removes the inventory records of the project whose service accounts no longer exist,
+// such as keys deleted outside of this server. Keys issued after the service accounts were listed are kept.
+func (m *Management) pruneAPIKeys(ctx context.Context, projectID string, existing map[string]bool, listedAt time.Time) error {
+	// Stores may keep times with second precision only.
+	listedAt = listedAt.Truncate(time.Second)
+	err := m.store.Update(ctx, func(tx store.Tx) error {
+		keys, err := tx.List()
+		if err != nil {
+			return err
+		}
+		for _, key := range *keys {
+			if key.ProjectID != projectID || existing[key.ServiceAccountID] || !key.IssuedAt.Before(listedAt) {
+				continue
+			}
+			slog.Info("forget api key of missing service account", "project_id", projectID, "service_account_id", key.ServiceAccountID, "owner", key.OwnerEmail)
+			if err := tx.Delete(key.ServiceAccountID); err != nil {
+				return err
 			}
 		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("prune api keys: %w", err)
 	}
 	return nil
 }
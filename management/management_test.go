@@ -3,19 +3,48 @@ package management
 import (
 	"context"
 	"errors"
+	"fmt"
+	"iter"
+	"net/http"
+	"slices"
 	"testing"
 	"time"
 
 	"github.com/hi120ki/monorepo/projects/openaikeyserver/client"
+	"github.com/hi120ki/monorepo/projects/openaikeyserver/store"
 )
 
 // MockClient is a mock implementation of client.APIClient
 type MockClient struct {
-	GetProjectFunc           func(ctx context.Context, projectName string) (*client.Project, bool, error)
-	CreateProjectFunc        func(ctx context.Context, name string) (*client.Project, error)
-	CreateServiceAccountFunc func(ctx context.Context, projectID string, name string) (*client.ServiceAccount, error)
-	ListServiceAccountsFunc  func(ctx context.Context, projectID string) (*[]client.ServiceAccount, error)
-	DeleteServiceAccountFunc func(ctx context.Context, projectID string, serviceAccountID string) (*client.DeletedServiceAccountResponse, error)
+	GetProjectFunc             func(ctx context.Context, projectName string) (*client.Project, bool, error)
+	GetProjectByIDFunc         func(ctx context.Context, projectID string) (*client.Project, error)
+	CreateProjectFunc          func(ctx context.Context, name string) (*client.Project, error)
+	ModifyProjectFunc          func(ctx context.Context, projectID string, name string) (*client.Project, error)
+	ArchiveProjectFunc         func(ctx context.Context, projectID string) (*client.Project, error)
+	CreateServiceAccountFunc   func(ctx context.Context, projectID string, name string) (*client.ServiceAccount, error)
+	GetServiceAccountFunc      func(ctx context.Context, projectID string, serviceAccountID string, keyID string) (*client.ServiceAccount, error)
+	ListServiceAccountsFunc    func(ctx context.Context, projectID string) (*[]client.ServiceAccount, error)
+	ServiceAccountsFunc        func(ctx context.Context, projectID string) iter.Seq2[client.ServiceAccount, error]
+	DeleteServiceAccountFunc   func(ctx context.Context, projectID string, serviceAccountID string) (*client.DeletedServiceAccountResponse, error)
+	ListProjectAPIKeysFunc     func(ctx context.Context, projectID string) (*[]client.ProjectAPIKey, error)
+	GetProjectAPIKeyFunc       func(ctx context.Context, projectID string, keyID string) (*client.ProjectAPIKey, error)
+	DeleteProjectAPIKeyFunc    func(ctx context.Context, projectID string, keyID string) (*client.DeletedProjectAPIKeyResponse, error)
+	ListProjectRateLimitsFunc  func(ctx context.Context, projectID string) (*[]client.ProjectRateLimit, error)
+	ModifyProjectRateLimitFunc func(ctx context.Context, projectID string, rateLimitID string, limits client.ModifyProjectRateLimitRequest) (*client.ProjectRateLimit, error)
+	ListUsersFunc              func(ctx context.Context) (*[]client.User, error)
+	GetUserFunc                func(ctx context.Context, userID string) (*client.User, error)
+	GetUserByEmailFunc         func(ctx context.Context, email string) (*client.User, bool, error)
+	ModifyUserRoleFunc         func(ctx context.Context, userID string, role string) (*client.User, error)
+	DeleteUserFunc             func(ctx context.Context, userID string) (*client.DeletedUserResponse, error)
+	ListInvitesFunc            func(ctx context.Context) (*[]client.Invite, error)
+	CreateInviteFunc           func(ctx context.Context, invite client.CreateInviteRequest) (*client.Invite, error)
+	GetInviteFunc              func(ctx context.Context, inviteID string) (*client.Invite, error)
+	DeleteInviteFunc           func(ctx context.Context, inviteID string) (*client.DeletedInviteResponse, error)
+	ListProjectUsersFunc       func(ctx context.Context, projectID string) (*[]client.ProjectUser, error)
+	AddProjectUserFunc         func(ctx context.Context, projectID string, userID string, role string) (*client.ProjectUser, error)
+	GetProjectUserFunc         func(ctx context.Context, projectID string, userID string) (*client.ProjectUser, error)
+	ModifyProjectUserRoleFunc  func(ctx context.Context, projectID string, userID string, role string) (*client.ProjectUser, error)
+	DeleteProjectUserFunc      func(ctx context.Context, projectID string, userID string) (*client.DeletedProjectUserResponse, error)
 }
 
 // Override methods with mock implementations
@@ -33,6 +62,27 @@ func (m *MockClient) CreateProject(ctx context.Context, name string) (*client.Pr
 	return nil, nil
 }
 
+func (m *MockClient) GetProjectByID(ctx context.Context, projectID string) (*client.Project, error) {
+	if m.GetProjectByIDFunc != nil {
+		return m.GetProjectByIDFunc(ctx, projectID)
+	}
+	return nil, nil
+}
+
+func (m *MockClient) ModifyProject(ctx context.Context, projectID string, name string) (*client.Project, error) {
+	if m.ModifyProjectFunc != nil {
+		return m.ModifyProjectFunc(ctx, projectID, name)
+	}
+	return nil, nil
+}
+
+func (m *MockClient) ArchiveProject(ctx context.Context, projectID string) (*client.Project, error) {
+	if m.ArchiveProjectFunc != nil {
+		return m.ArchiveProjectFunc(ctx, projectID)
+	}
+	return nil, nil
+}
+
 func (m *MockClient) CreateServiceAccount(ctx context.Context, projectID string, name string) (*client.ServiceAccount, error) {
 	if m.CreateServiceAccountFunc != nil {
 		return m.CreateServiceAccountFunc(ctx, projectID, name)
@@ -40,6 +90,13 @@ func (m *MockClient) CreateServiceAccount(ctx context.Context, projectID string,
 	return nil, nil
 }
 
+func (m *MockClient) GetServiceAccount(ctx context.Context, projectID string, serviceAccountID string, keyID string) (*client.ServiceAccount, error) {
+	if m.GetServiceAccountFunc != nil {
+		return m.GetServiceAccountFunc(ctx, projectID, serviceAccountID, keyID)
+	}
+	return nil, nil
+}
+
 func (m *MockClient) ListServiceAccounts(ctx context.Context, projectID string) (*[]client.ServiceAccount, error) {
 	if m.ListServiceAccountsFunc != nil {
 		return m.ListServiceAccountsFunc(ctx, projectID)
@@ -47,6 +104,28 @@ func (m *MockClient) ListServiceAccounts(ctx context.Context, projectID string)
 	return nil, nil
 }
 
+// ServiceAccounts falls back to iterating over ListServiceAccountsFunc when ServiceAccountsFunc is not set.
+func (m *MockClient) ServiceAccounts(ctx context.Context, projectID string) iter.Seq2[client.ServiceAccount, error] {
+	if m.ServiceAccountsFunc != nil {
+		return m.ServiceAccountsFunc(ctx, projectID)
+	}
+	return func(yield func(client.ServiceAccount, error) bool) {
+		serviceAccounts, err := m.ListServiceAccounts(ctx, projectID)
+		if err != nil {
+			yield(client.ServiceAccount{}, err)
+			return
+		}
+		if serviceAccounts == nil {
+			return
+		}
+		for _, serviceAccount := range *serviceAccounts {
+			if !yield(serviceAccount, nil) {
+				return
+			}
+		}
+	}
+}
+
 func (m *MockClient) DeleteServiceAccount(ctx context.Context, projectID string, serviceAccountID string) (*client.DeletedServiceAccountResponse, error) {
 	if m.DeleteServiceAccountFunc != nil {
 		return m.DeleteServiceAccountFunc(ctx, projectID, serviceAccountID)
@@ -54,13 +133,146 @@ func (m *MockClient) DeleteServiceAccount(ctx context.Context, projectID string,
 	return nil, nil
 }
 
+func (m *MockClient) ListProjectAPIKeys(ctx context.Context, projectID string) (*[]client.ProjectAPIKey, error) {
+	if m.ListProjectAPIKeysFunc != nil {
+		return m.ListProjectAPIKeysFunc(ctx, projectID)
+	}
+	return nil, nil
+}
+
+func (m *MockClient) GetProjectAPIKey(ctx context.Context, projectID string, keyID string) (*client.ProjectAPIKey, error) {
+	if m.GetProjectAPIKeyFunc != nil {
+		return m.GetProjectAPIKeyFunc(ctx, projectID, keyID)
+	}
+	return nil, nil
+}
+
+func (m *MockClient) DeleteProjectAPIKey(ctx context.Context, projectID string, keyID string) (*client.DeletedProjectAPIKeyResponse, error) {
+	if m.DeleteProjectAPIKeyFunc != nil {
+		return m.DeleteProjectAPIKeyFunc(ctx, projectID, keyID)
+	}
+	return nil, nil
+}
+
+func (m *MockClient) ListProjectRateLimits(ctx context.Context, projectID string) (*[]client.ProjectRateLimit, error) {
+	if m.ListProjectRateLimitsFunc != nil {
+		return m.ListProjectRateLimitsFunc(ctx, projectID)
+	}
+	return nil, nil
+}
+
+func (m *MockClient) ModifyProjectRateLimit(ctx context.Context, projectID string, rateLimitID string, limits client.ModifyProjectRateLimitRequest) (*client.ProjectRateLimit, error) {
+	if m.ModifyProjectRateLimitFunc != nil {
+		return m.ModifyProjectRateLimitFunc(ctx, projectID, rateLimitID, limits)
+	}
+	return nil, nil
+}
+
+func (m *MockClient) ListUsers(ctx context.Context) (*[]client.User, error) {
+	if m.ListUsersFunc != nil {
+		return m.ListUsersFunc(ctx)
+	}
+	return nil, nil
+}
+
+func (m *MockClient) GetUser(ctx context.Context, userID string) (*client.User, error) {
+	if m.GetUserFunc != nil {
+		return m.GetUserFunc(ctx, userID)
+	}
+	return nil, nil
+}
+
+func (m *MockClient) GetUserByEmail(ctx context.Context, email string) (*client.User, bool, error) {
+	if m.GetUserByEmailFunc != nil {
+		return m.GetUserByEmailFunc(ctx, email)
+	}
+	return nil, false, nil
+}
+
+func (m *MockClient) ModifyUserRole(ctx context.Context, userID string, role string) (*client.User, error) {
+	if m.ModifyUserRoleFunc != nil {
+		return m.ModifyUserRoleFunc(ctx, userID, role)
+	}
+	return nil, nil
+}
+
+func (m *MockClient) DeleteUser(ctx context.Context, userID string) (*client.DeletedUserResponse, error) {
+	if m.DeleteUserFunc != nil {
+		return m.DeleteUserFunc(ctx, userID)
+	}
+	return nil, nil
+}
+
+func (m *MockClient) ListInvites(ctx context.Context) (*[]client.Invite, error) {
+	if m.ListInvitesFunc != nil {
+		return m.ListInvitesFunc(ctx)
+	}
+	return nil, nil
+}
+
+func (m *MockClient) CreateInvite(ctx context.Context, invite client.CreateInviteRequest) (*client.Invite, error) {
+	if m.CreateInviteFunc != nil {
+		return m.CreateInviteFunc(ctx, invite)
+	}
+	return nil, nil
+}
+
+func (m *MockClient) GetInvite(ctx context.Context, inviteID string) (*client.Invite, error) {
+	if m.GetInviteFunc != nil {
+		return m.GetInviteFunc(ctx, inviteID)
+	}
+	return nil, nil
+}
+
+func (m *MockClient) DeleteInvite(ctx context.Context, inviteID string) (*client.DeletedInviteResponse, error) {
+	if m.DeleteInviteFunc != nil {
+		return m.DeleteInviteFunc(ctx, inviteID)
+	}
+	return nil, nil
+}
+
+func (m *MockClient) ListProjectUsers(ctx context.Context, projectID string) (*[]client.ProjectUser, error) {
+	if m.ListProjectUsersFunc != nil {
+		return m.ListProjectUsersFunc(ctx, projectID)
+	}
+	return nil, nil
+}
+
+func (m *MockClient) AddProjectUser(ctx context.Context, projectID string, userID string, role string) (*client.ProjectUser, error) {
+	if m.AddProjectUserFunc != nil {
+		return m.AddProjectUserFunc(ctx, projectID, userID, role)
+	}
+	return nil, nil
+}
+
+func (m *MockClient) GetProjectUser(ctx context.Context, projectID string, userID string) (*client.ProjectUser, error) {
+	if m.GetProjectUserFunc != nil {
+		return m.GetProjectUserFunc(ctx, projectID, userID)
+	}
+	return nil, nil
+}
+
+func (m *MockClient) ModifyProjectUserRole(ctx context.Context, projectID string, userID string, role string) (*client.ProjectUser, error) {
+	if m.ModifyProjectUserRoleFunc != nil {
+		return m.ModifyProjectUserRoleFunc(ctx, projectID, userID, role)
+	}
+	return nil, nil
+}
+
+func (m *MockClient) DeleteProjectUser(ctx context.Context, projectID string, userID string) (*client.DeletedProjectUserResponse, error) {
+	if m.DeleteProjectUserFunc != nil {
+		return m.DeleteProjectUserFunc(ctx, projectID, userID)
+	}
+	return nil, nil
+}
+
 func TestNewManagement(t *testing.T) {
 	// Test data
 	client := &MockClient{}
 	expiration := 24 * time.Hour
 
 	// Test NewManagement
-	m := NewManagement(client, expiration)
+	m := NewManagement(client, expiration, nil)
 
 	// Verify result
 	if m == nil {
@@ -91,19 +303,13 @@ func TestCreateAPIKey_ExistingProject(t *testing.T) {
 			if projID != projectID {
 				t.Errorf("Expected project ID to be '%s', got '%s'", projectID, projID)
 			}
-			if name != serviceAccountName {
-				t.Errorf("Expected service account name to be '%s', got '%s'", serviceAccountName, name)
+			if keyName, err := parseKeyName(name); err != nil || keyName.Owner != serviceAccountName {
+				t.Errorf("Expected service account name to encode owner '%s', got '%s'", serviceAccountName, name)
 			}
 			return &client.ServiceAccount{
 				ID:   "sa_123",
-				Name: serviceAccountName,
-				APIKey: struct {
-					Object    string `json:"object"`
-					Value     string `json:"value"`
-					Name      string `json:"name"`
-					CreatedAt int64  `json:"created_at"`
-					ID        string `json:"id"`
-				}{
+				Name: name,
+				APIKey: client.ServiceAccountAPIKey{
 					Value: apiKeyValue,
 				},
 			}, nil
@@ -111,10 +317,10 @@ func TestCreateAPIKey_ExistingProject(t *testing.T) {
 	}
 
 	// Create management
-	management := NewManagement(mockClient, expiration)
+	management := NewManagement(mockClient, expiration, nil)
 
 	// Test CreateAPIKey
-	key, expirationTime, err := management.CreateAPIKey(context.Background(), projectName, serviceAccountName)
+	key, expirationTime, err := management.CreateAPIKey(context.Background(), projectName, serviceAccountName, 0)
 
 	// Verify result
 	if err != nil {
@@ -157,19 +363,13 @@ func TestCreateAPIKey_NewProject(t *testing.T) {
 			if projID != projectID {
 				t.Errorf("Expected project ID to be '%s', got '%s'", projectID, projID)
 			}
-			if name != serviceAccountName {
-				t.Errorf("Expected service account name to be '%s', got '%s'", serviceAccountName, name)
+			if keyName, err := parseKeyName(name); err != nil || keyName.Owner != serviceAccountName {
+				t.Errorf("Expected service account name to encode owner '%s', got '%s'", serviceAccountName, name)
 			}
 			return &client.ServiceAccount{
 				ID:   "sa_123",
-				Name: serviceAccountName,
-				APIKey: struct {
-					Object    string `json:"object"`
-					Value     string `json:"value"`
-					Name      string `json:"name"`
-					CreatedAt int64  `json:"created_at"`
-					ID        string `json:"id"`
-				}{
+				Name: name,
+				APIKey: client.ServiceAccountAPIKey{
 					Value: apiKeyValue,
 				},
 			}, nil
@@ -177,10 +377,10 @@ func TestCreateAPIKey_NewProject(t *testing.T) {
 	}
 
 	// Create management
-	management := NewManagement(mockClient, expiration)
+	management := NewManagement(mockClient, expiration, nil)
 
 	// Test CreateAPIKey
-	key, expirationTime, err := management.CreateAPIKey(context.Background(), projectName, serviceAccountName)
+	key, expirationTime, err := management.CreateAPIKey(context.Background(), projectName, serviceAccountName, 0)
 
 	// Verify result
 	if err != nil {
@@ -209,10 +409,10 @@ func TestCreateAPIKey_GetProjectError(t *testing.T) {
 	}
 
 	// Create management
-	management := NewManagement(mockClient, expiration)
+	management := NewManagement(mockClient, expiration, nil)
 
 	// Test CreateAPIKey
-	_, _, err := management.CreateAPIKey(context.Background(), projectName, serviceAccountName)
+	_, _, err := management.CreateAPIKey(context.Background(), projectName, serviceAccountName, 0)
 
 	// Verify result
 	if err == nil {
@@ -238,10 +438,10 @@ func TestCreateAPIKey_CreateProjectError(t *testing.T) {
 	}
 
 	// Create management
-	management := NewManagement(mockClient, expiration)
+	management := NewManagement(mockClient, expiration, nil)
 
 	// Test CreateAPIKey
-	_, _, err := management.CreateAPIKey(context.Background(), projectName, serviceAccountName)
+	_, _, err := management.CreateAPIKey(context.Background(), projectName, serviceAccountName, 0)
 
 	// Verify result
 	if err == nil {
@@ -249,6 +449,114 @@ func TestCreateAPIKey_CreateProjectError(t *testing.T) {
 	}
 }
 
+func TestCreateAPIKey_CreateProjectConflict(t *testing.T) {
+	// Test data
+	projectName := "test-project"
+	serviceAccountName := "test-service-account"
+	projectID := "proj_123"
+	expiration := 24 * time.Hour
+	getProjectCalls := 0
+
+	// Create mock client
+	mockClient := &MockClient{
+		GetProjectFunc: func(ctx context.Context, name string) (*client.Project, bool, error) {
+			getProjectCalls++
+			if getProjectCalls == 1 {
+				return nil, false, nil
+			}
+			return &client.Project{
+				ID:   projectID,
+				Name: projectName,
+			}, true, nil
+		},
+		CreateProjectFunc: func(ctx context.Context, name string) (*client.Project, error) {
+			return nil, &client.APIError{StatusCode: 409, Message: "Project already exists"}
+		},
+		CreateServiceAccountFunc: func(ctx context.Context, projID string, name string) (*client.ServiceAccount, error) {
+			if projID != projectID {
+				t.Errorf("Expected project ID to be '%s', got '%s'", projectID, projID)
+			}
+			return &client.ServiceAccount{ID: "sa_123", Name: name}, nil
+		},
+	}
+
+	// Create management
+	management := NewManagement(mockClient, expiration, nil)
+
+	// Test CreateAPIKey
+	_, _, err := management.CreateAPIKey(context.Background(), projectName, serviceAccountName, 0)
+
+	// Verify result
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if getProjectCalls != 2 {
+		t.Errorf("Expected 2 GetProject calls, got %d", getProjectCalls)
+	}
+}
+
+func TestCreateAPIKey_Unauthorized(t *testing.T) {
+	// Test data
+	projectName := "test-project"
+	serviceAccountName := "test-service-account"
+	expiration := 24 * time.Hour
+
+	// Create mock client
+	mockClient := &MockClient{
+		GetProjectFunc: func(ctx context.Context, name string) (*client.Project, bool, error) {
+			return nil, false, &client.APIError{StatusCode: 401, Message: "Incorrect API key provided"}
+		},
+	}
+
+	// Create management
+	management := NewManagement(mockClient, expiration, nil)
+
+	// Test CreateAPIKey
+	_, _, err := management.CreateAPIKey(context.Background(), projectName, serviceAccountName, 0)
+
+	// Verify result
+	if !errors.Is(err, client.ErrUnauthorized) {
+		t.Errorf("Expected ErrUnauthorized, got %v", err)
+	}
+}
+
+func TestCreateAPIKey_ArchivedProject(t *testing.T) {
+	// Test data
+	projectName := "test-project"
+	serviceAccountName := "test-service-account"
+	expiration := 24 * time.Hour
+
+	// Create mock client
+	mockClient := &MockClient{
+		GetProjectFunc: func(ctx context.Context, name string) (*client.Project, bool, error) {
+			return &client.Project{
+				ID:     "proj_123",
+				Name:   projectName,
+				Status: "archived",
+			}, true, nil
+		},
+		CreateProjectFunc: func(ctx context.Context, name string) (*client.Project, error) {
+			t.Error("Expected CreateProject not to be called for an archived project")
+			return nil, nil
+		},
+		CreateServiceAccountFunc: func(ctx context.Context, projID string, name string) (*client.ServiceAccount, error) {
+			t.Error("Expected CreateServiceAccount not to be called for an archived project")
+			return nil, nil
+		},
+	}
+
+	// Create management
+	management := NewManagement(mockClient, expiration, nil)
+
+	// Test CreateAPIKey
+	_, _, err := management.CreateAPIKey(context.Background(), projectName, serviceAccountName, 0)
+
+	// Verify result
+	if !errors.Is(err, ErrProjectArchived) {
+		t.Errorf("Expected ErrProjectArchived, got %v", err)
+	}
+}
+
 func TestCreateAPIKey_CreateServiceAccountError(t *testing.T) {
 	// Test data
 	projectName := "test-project"
@@ -271,10 +579,10 @@ func TestCreateAPIKey_CreateServiceAccountError(t *testing.T) {
 	}
 
 	// Create management
-	management := NewManagement(mockClient, expiration)
+	management := NewManagement(mockClient, expiration, nil)
 
 	// Test CreateAPIKey
-	_, _, err := management.CreateAPIKey(context.Background(), projectName, serviceAccountName)
+	_, _, err := management.CreateAPIKey(context.Background(), projectName, serviceAccountName, 0)
 
 	// Verify result
 	if err == nil {
@@ -290,6 +598,7 @@ func TestCleanupAPIKey(t *testing.T) {
 	now := time.Now()
 	oldTime := now.Add(-2 * expiration).Unix()
 	newTime := now.Add(-1 * time.Hour).Unix()
+	deleted := 0
 
 	// Create mock client
 	mockClient := &MockClient{
@@ -309,14 +618,19 @@ func TestCleanupAPIKey(t *testing.T) {
 			return &[]client.ServiceAccount{
 				{
 					ID:        "sa_old",
-					Name:      "old-service-account",
+					Name:      keyName{Owner: "old@example.com", ExpiresAt: time.Unix(oldTime, 0).Add(expiration)}.String(),
 					CreatedAt: oldTime,
 				},
 				{
 					ID:        "sa_new",
-					Name:      "new-service-account",
+					Name:      keyName{Owner: "new@example.com", ExpiresAt: time.Unix(newTime, 0).Add(expiration)}.String(),
 					CreatedAt: newTime,
 				},
+				{
+					ID:        "sa_manual",
+					Name:      "production-workload",
+					CreatedAt: oldTime,
+				},
 			}, nil
 		},
 		DeleteServiceAccountFunc: func(ctx context.Context, projID string, serviceAccountID string) (*client.DeletedServiceAccountResponse, error) {
@@ -326,6 +640,10 @@ func TestCleanupAPIKey(t *testing.T) {
 			if serviceAccountID != "sa_old" {
 				t.Errorf("Expected service account ID to be 'sa_old', got '%s'", serviceAccountID)
 			}
+			if p := client.PriorityFromContext(ctx); p != client.PriorityBackground {
+				t.Errorf("Expected cleanup requests to have background priority, got %v", p)
+			}
+			deleted++
 			return &client.DeletedServiceAccountResponse{
 				ID:      serviceAccountID,
 				Deleted: true,
@@ -334,14 +652,20 @@ func TestCleanupAPIKey(t *testing.T) {
 	}
 
 	// Create management
-	management := NewManagement(mockClient, expiration)
+	management := NewManagement(mockClient, expiration, nil)
 
 	// Test CleanupAPIKey
-	err := management.CleanupAPIKey(context.Background(), projectName)
+	report, err := management.CleanupAPIKey(context.Background(), projectName, false)
 
 	// Verify result
 	if err != nil {
-		t.Errorf("Unexpected error: %v", err)
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if deleted != 1 {
+		t.Errorf("Expected 1 deleted service account, got %d", deleted)
+	}
+	if report.DryRun || len(report.Entries) != 1 || report.Entries[0].ServiceAccountID != "sa_old" || report.Entries[0].Owner != "old@example.com" {
+		t.Errorf("Expected a report of the deleted service account, got %+v", report)
 	}
 }
 
@@ -359,10 +683,10 @@ func TestCleanupAPIKey_GetProjectError(t *testing.T) {
 	}
 
 	// Create management
-	management := NewManagement(mockClient, expiration)
+	management := NewManagement(mockClient, expiration, nil)
 
 	// Test CleanupAPIKey
-	err := management.CleanupAPIKey(context.Background(), projectName)
+	_, err := management.CleanupAPIKey(context.Background(), projectName, false)
 
 	// Verify result
 	if err == nil {
@@ -383,10 +707,10 @@ func TestCleanupAPIKey_ProjectNotFound(t *testing.T) {
 	}
 
 	// Create management
-	management := NewManagement(mockClient, expiration)
+	management := NewManagement(mockClient, expiration, nil)
 
 	// Test CleanupAPIKey
-	err := management.CleanupAPIKey(context.Background(), projectName)
+	_, err := management.CleanupAPIKey(context.Background(), projectName, false)
 
 	// Verify result
 	if err == nil {
@@ -415,10 +739,10 @@ func TestCleanupAPIKey_ListServiceAccountsError(t *testing.T) {
 	}
 
 	// Create management
-	management := NewManagement(mockClient, expiration)
+	management := NewManagement(mockClient, expiration, nil)
 
 	// Test CleanupAPIKey
-	err := management.CleanupAPIKey(context.Background(), projectName)
+	_, err := management.CleanupAPIKey(context.Background(), projectName, false)
 
 	// Verify result
 	if err == nil {
@@ -447,7 +771,7 @@ func TestCleanupAPIKey_DeleteServiceAccountError(t *testing.T) {
 			return &[]client.ServiceAccount{
 				{
 					ID:        "sa_old",
-					Name:      "old-service-account",
+					Name:      keyName{Owner: "old@example.com", ExpiresAt: time.Unix(oldTime, 0).Add(expiration)}.String(),
 					CreatedAt: oldTime,
 				},
 			}, nil
@@ -458,13 +782,749 @@ func TestCleanupAPIKey_DeleteServiceAccountError(t *testing.T) {
 	}
 
 	// Create management
-	management := NewManagement(mockClient, expiration)
+	management := NewManagement(mockClient, expiration, nil)
 
 	// Test CleanupAPIKey
-	err := management.CleanupAPIKey(context.Background(), projectName)
+	_, err := management.CleanupAPIKey(context.Background(), projectName, false)
 
 	// Verify result
 	if err == nil {
 		t.Error("Expected error, got nil")
 	}
 }
+
+func TestCleanupAPIKey_ArchivedProject(t *testing.T) {
+	// Test data
+	projectName := "test-project"
+	expiration := 24 * time.Hour
+
+	// Create mock client
+	mockClient := &MockClient{
+		GetProjectFunc: func(ctx context.Context, name string) (*client.Project, bool, error) {
+			return &client.Project{
+				ID:     "proj_123",
+				Name:   projectName,
+				Status: "archived",
+			}, true, nil
+		},
+		ListServiceAccountsFunc: func(ctx context.Context, projID string) (*[]client.ServiceAccount, error) {
+			t.Error("Expected ListServiceAccounts not to be called for an archived project")
+			return nil, nil
+		},
+	}
+
+	// Create management
+	management := NewManagement(mockClient, expiration, nil)
+
+	// Test CleanupAPIKey
+	_, err := management.CleanupAPIKey(context.Background(), projectName, false)
+
+	// Verify result
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
+
+func TestCleanupAPIKey_ServiceAccountsIteratorError(t *testing.T) {
+	// Test data
+	projectName := "test-project"
+	expiration := 24 * time.Hour
+	expectedError := errors.New("list service accounts error")
+	deleted := false
+
+	// Create mock client whose iterator fails after the first page
+	mockClient := &MockClient{
+		GetProjectFunc: func(ctx context.Context, name string) (*client.Project, bool, error) {
+			return &client.Project{ID: "proj_123", Name: projectName}, true, nil
+		},
+		ServiceAccountsFunc: func(ctx context.Context, projectID string) iter.Seq2[client.ServiceAccount, error] {
+			return func(yield func(client.ServiceAccount, error) bool) {
+				old := client.ServiceAccount{ID: "sa_old", CreatedAt: time.Now().Add(-2 * expiration).Unix()}
+				if !yield(old, nil) {
+					return
+				}
+				yield(client.ServiceAccount{}, expectedError)
+			}
+		},
+		DeleteServiceAccountFunc: func(ctx context.Context, projectID string, serviceAccountID string) (*client.DeletedServiceAccountResponse, error) {
+			deleted = true
+			return &client.DeletedServiceAccountResponse{ID: serviceAccountID, Deleted: true}, nil
+		},
+	}
+
+	// Create management
+	management := NewManagement(mockClient, expiration, nil)
+
+	// Test CleanupAPIKey
+	_, err := management.CleanupAPIKey(context.Background(), projectName, false)
+
+	// Verify result
+	if !errors.Is(err, expectedError) {
+		t.Errorf("Expected error to be %v, got %v", expectedError, err)
+	}
+	if deleted {
+		t.Error("Expected no service account to be deleted when listing fails")
+	}
+}
+
+// failingStore is a store.Store whose transactions always fail.
+type failingStore struct {
+	err error
+}
+
+func (s *failingStore) Update(ctx context.Context, fn func(tx store.Tx) error) error {
+	return s.err
+}
+
+func (s *failingStore) View(ctx context.Context, fn func(tx store.Tx) error) error {
+	return s.err
+}
+
+func (s *failingStore) Close() error {
+	return nil
+}
+
+// readOnlyStore is a store.Store whose reads succeed and whose writes always fail.
+type readOnlyStore struct {
+	store.Store
+	err error
+}
+
+func (s *readOnlyStore) Update(ctx context.Context, fn func(tx store.Tx) error) error {
+	return s.err
+}
+
+func TestCreateAPIKey_RecordsKey(t *testing.T) {
+	// Test data
+	projectID := "proj_123"
+	serviceAccountName := "user@example.com"
+	expiration := 24 * time.Hour
+
+	// Create mock client
+	mockClient := &MockClient{
+		GetProjectFunc: func(ctx context.Context, name string) (*client.Project, bool, error) {
+			return &client.Project{ID: projectID, Name: name}, true, nil
+		},
+		CreateServiceAccountFunc: func(ctx context.Context, projID string, name string) (*client.ServiceAccount, error) {
+			return &client.ServiceAccount{
+				ID:     "sa_123",
+				Name:   name,
+				APIKey: client.ServiceAccountAPIKey{ID: "key_123", Value: "sk-test-key"},
+			}, nil
+		},
+	}
+
+	// Create management
+	management := NewManagement(mockClient, expiration, store.NewMemoryStore())
+
+	// Test CreateAPIKey
+	_, expiresAt, err := management.CreateAPIKey(context.Background(), "test-project", serviceAccountName, 0)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// Verify result
+	keys, err := management.ListAPIKeys(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(*keys) != 1 {
+		t.Fatalf("Expected 1 recorded key, got %d", len(*keys))
+	}
+	key := (*keys)[0]
+	if key.OwnerEmail != serviceAccountName || key.ProjectID != projectID || key.ServiceAccountID != "sa_123" || key.KeyID != "key_123" || key.Label != (keyName{Owner: serviceAccountName, ExpiresAt: key.ExpiresAt}).String() {
+		t.Errorf("Unexpected recorded key: %+v", key)
+	}
+	if !key.ExpiresAt.Equal(*expiresAt) || !key.ExpiresAt.Equal(key.IssuedAt.Add(expiration)) {
+		t.Errorf("Expected key to expire at %v, %v after issue, got %+v", *expiresAt, expiration, key)
+	}
+}
+
+func TestCreateAPIKey_StoreError(t *testing.T) {
+	// Test data
+	projectID := "proj_123"
+	expectedError := errors.New("store error")
+	deleted := ""
+
+	// Create mock client
+	mockClient := &MockClient{
+		GetProjectFunc: func(ctx context.Context, name string) (*client.Project, bool, error) {
+			return &client.Project{ID: projectID, Name: name}, true, nil
+		},
+		CreateServiceAccountFunc: func(ctx context.Context, projID string, name string) (*client.ServiceAccount, error) {
+			return &client.ServiceAccount{ID: "sa_123", Name: name, APIKey: client.ServiceAccountAPIKey{Value: "sk-test-key"}}, nil
+		},
+		DeleteServiceAccountFunc: func(ctx context.Context, projID string, serviceAccountID string) (*client.DeletedServiceAccountResponse, error) {
+			deleted = serviceAccountID
+			return &client.DeletedServiceAccountResponse{ID: serviceAccountID, Deleted: true}, nil
+		},
+	}
+
+	// Create management
+	management := NewManagement(mockClient, 24*time.Hour, &failingStore{err: expectedError})
+
+	// Test CreateAPIKey
+	key, _, err := management.CreateAPIKey(context.Background(), "test-project", "user@example.com", 0)
+
+	// Verify result: the unrecorded key is not handed out and its service account is removed
+	if !errors.Is(err, expectedError) {
+		t.Errorf("Expected error to be %v, got %v", expectedError, err)
+	}
+	if key != "" {
+		t.Errorf("Expected no API key, got '%s'", key)
+	}
+	if deleted != "sa_123" {
+		t.Errorf("Expected service account sa_123 to be deleted, got '%s'", deleted)
+	}
+}
+
+func TestCleanupAPIKey_UpdatesStore(t *testing.T) {
+	// Test data
+	projectID := "proj_123"
+	expiration := 24 * time.Hour
+	now := time.Now()
+	keyStore := store.NewMemoryStore()
+	err := keyStore.Update(context.Background(), func(tx store.Tx) error {
+		for _, key := range []store.Key{
+			{ProjectID: projectID, ServiceAccountID: "sa_old", IssuedAt: now.Add(-2 * expiration)},
+			{ProjectID: projectID, ServiceAccountID: "sa_new", IssuedAt: now.Add(-1 * time.Hour)},
+			{ProjectID: projectID, ServiceAccountID: "sa_gone", IssuedAt: now.Add(-1 * time.Hour)},
+			{ProjectID: "proj_other", ServiceAccountID: "sa_other", IssuedAt: now.Add(-1 * time.Hour)},
+			{ProjectID: projectID, ServiceAccountID: "sa_issuing", IssuedAt: now.Add(time.Hour)},
+		} {
+			key.ExpiresAt = key.IssuedAt.Add(expiration)
+			if err := tx.Put(key); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// Create mock client
+	mockClient := &MockClient{
+		GetProjectFunc: func(ctx context.Context, name string) (*client.Project, bool, error) {
+			return &client.Project{ID: projectID, Name: name}, true, nil
+		},
+		ListServiceAccountsFunc: func(ctx context.Context, projID string) (*[]client.ServiceAccount, error) {
+			return &[]client.ServiceAccount{
+				{ID: "sa_old", CreatedAt: now.Add(-2 * expiration).Unix()},
+				{ID: "sa_new", CreatedAt: now.Add(-1 * time.Hour).Unix()},
+			}, nil
+		},
+		DeleteServiceAccountFunc: func(ctx context.Context, projID string, serviceAccountID string) (*client.DeletedServiceAccountResponse, error) {
+			return &client.DeletedServiceAccountResponse{ID: serviceAccountID, Deleted: true}, nil
+		},
+	}
+
+	// Create management
+	management := NewManagement(mockClient, expiration, keyStore)
+
+	// Test CleanupAPIKey
+	if _, err := management.CleanupAPIKey(context.Background(), "test-project", false); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// Verify result: deleted and vanished service accounts are forgotten, others are kept
+	keys, err := management.ListAPIKeys(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	var remaining []string
+	for _, key := range *keys {
+		remaining = append(remaining, key.ServiceAccountID)
+	}
+	expected := []string{"sa_new", "sa_other", "sa_issuing"}
+	if !slices.Equal(remaining, expected) {
+		t.Errorf("Expected remaining keys %v, got %v", expected, remaining)
+	}
+}
+
+func TestCleanupAPIKey_DeleteErrorKeepsKey(t *testing.T) {
+	// Test data
+	projectID := "proj_123"
+	expiration := 24 * time.Hour
+	expectedError := errors.New("delete service account error")
+	keyStore := store.NewMemoryStore()
+	err := keyStore.Update(context.Background(), func(tx store.Tx) error {
+		return tx.Put(store.Key{ProjectID: projectID, ServiceAccountID: "sa_old", IssuedAt: time.Now().Add(-2 * expiration), ExpiresAt: time.Now().Add(-1 * expiration)})
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// Create mock client
+	mockClient := &MockClient{
+		GetProjectFunc: func(ctx context.Context, name string) (*client.Project, bool, error) {
+			return &client.Project{ID: projectID, Name: name}, true, nil
+		},
+		ListServiceAccountsFunc: func(ctx context.Context, projID string) (*[]client.ServiceAccount, error) {
+			return &[]client.ServiceAccount{{ID: "sa_old", CreatedAt: time.Now().Add(-2 * expiration).Unix()}}, nil
+		},
+		DeleteServiceAccountFunc: func(ctx context.Context, projID string, serviceAccountID string) (*client.DeletedServiceAccountResponse, error) {
+			return nil, expectedError
+		},
+	}
+
+	// Create management
+	management := NewManagement(mockClient, expiration, keyStore)
+
+	// Test CleanupAPIKey
+	_, err = management.CleanupAPIKey(context.Background(), "test-project", false)
+
+	// Verify result: the key is still recorded because its service account still exists
+	if !errors.Is(err, expectedError) {
+		t.Errorf("Expected error to be %v, got %v", expectedError, err)
+	}
+	keys, err := management.ListAPIKeys(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(*keys) != 1 {
+		t.Errorf("Expected the key to remain recorded, got %+v", *keys)
+	}
+}
+
+func TestCleanupAPIKey_DeleteOutsideTransaction(t *testing.T) {
+	// Test data
+	projectID := "proj_123"
+	expiration := 24 * time.Hour
+	keyStore := store.NewMemoryStore()
+	err := keyStore.Update(context.Background(), func(tx store.Tx) error {
+		return tx.Put(store.Key{ProjectID: projectID, ServiceAccountID: "sa_old", IssuedAt: time.Now().Add(-2 * expiration), ExpiresAt: time.Now().Add(-1 * expiration)})
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// Create mock client: key issuance writes to the store while a deletion is in flight
+	mockClient := &MockClient{
+		GetProjectFunc: func(ctx context.Context, name string) (*client.Project, bool, error) {
+			return &client.Project{ID: projectID, Name: name}, true, nil
+		},
+		ListServiceAccountsFunc: func(ctx context.Context, projID string) (*[]client.ServiceAccount, error) {
+			return &[]client.ServiceAccount{{ID: "sa_old", CreatedAt: time.Now().Add(-2 * expiration).Unix()}}, nil
+		},
+		DeleteServiceAccountFunc: func(ctx context.Context, projID string, serviceAccountID string) (*client.DeletedServiceAccountResponse, error) {
+			done := make(chan error, 1)
+			go func() {
+				done <- keyStore.Update(ctx, func(tx store.Tx) error {
+					return tx.Put(store.Key{ProjectID: projectID, ServiceAccountID: "sa_issued", IssuedAt: time.Now(), ExpiresAt: time.Now().Add(expiration)})
+				})
+			}()
+			select {
+			case err := <-done:
+				if err != nil {
+					t.Errorf("Unexpected error: %v", err)
+				}
+			case <-time.After(time.Second):
+				t.Error("Expected the store to be writable while the service account is deleted")
+			}
+			return &client.DeletedServiceAccountResponse{ID: serviceAccountID, Deleted: true}, nil
+		},
+	}
+
+	// Create management
+	management := NewManagement(mockClient, expiration, keyStore)
+
+	// Test CleanupAPIKey
+	if _, err := management.CleanupAPIKey(context.Background(), "test-project", false); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+}
+
+func TestCleanupAPIKey_AlreadyDeleted(t *testing.T) {
+	// Test data
+	projectID := "proj_123"
+	expiration := 24 * time.Hour
+	keyStore := store.NewMemoryStore()
+	err := keyStore.Update(context.Background(), func(tx store.Tx) error {
+		return tx.Put(store.Key{ProjectID: projectID, ServiceAccountID: "sa_old", IssuedAt: time.Now().Add(-2 * expiration), ExpiresAt: time.Now().Add(-1 * expiration)})
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// Create mock client
+	mockClient := &MockClient{
+		GetProjectFunc: func(ctx context.Context, name string) (*client.Project, bool, error) {
+			return &client.Project{ID: projectID, Name: name}, true, nil
+		},
+		ListServiceAccountsFunc: func(ctx context.Context, projID string) (*[]client.ServiceAccount, error) {
+			return &[]client.ServiceAccount{{ID: "sa_old", CreatedAt: time.Now().Add(-2 * expiration).Unix()}}, nil
+		},
+		DeleteServiceAccountFunc: func(ctx context.Context, projID string, serviceAccountID string) (*client.DeletedServiceAccountResponse, error) {
+			return nil, fmt.Errorf("delete service account: %w", &client.APIError{StatusCode: http.StatusNotFound})
+		},
+	}
+
+	// Create management
+	management := NewManagement(mockClient, expiration, keyStore)
+
+	// Test CleanupAPIKey
+	_, err = management.CleanupAPIKey(context.Background(), "test-project", false)
+
+	// Verify result: a service account deleted concurrently is forgotten
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	keys, err := management.ListAPIKeys(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(*keys) != 0 {
+		t.Errorf("Expected the key to be forgotten, got %+v", *keys)
+	}
+}
+
+func TestCleanupAPIKey_PartialReport(t *testing.T) {
+	// Test data
+	projectID := "proj_123"
+	expiration := 24 * time.Hour
+	oldTime := time.Now().Add(-2 * expiration)
+	expectedError := errors.New("delete service account error")
+	var deleted []string
+
+	// Create mock client
+	mockClient := &MockClient{
+		GetProjectFunc: func(ctx context.Context, name string) (*client.Project, bool, error) {
+			return &client.Project{ID: projectID, Name: name}, true, nil
+		},
+		ListServiceAccountsFunc: func(ctx context.Context, projID string) (*[]client.ServiceAccount, error) {
+			return &[]client.ServiceAccount{
+				{ID: "sa_first", Name: keyName{Owner: "first@example.com", ExpiresAt: oldTime.Add(expiration)}.String(), CreatedAt: oldTime.Unix()},
+				{ID: "sa_second", Name: keyName{Owner: "second@example.com", ExpiresAt: oldTime.Add(expiration)}.String(), CreatedAt: oldTime.Unix()},
+			}, nil
+		},
+		DeleteServiceAccountFunc: func(ctx context.Context, projID string, serviceAccountID string) (*client.DeletedServiceAccountResponse, error) {
+			if len(deleted) == 1 {
+				return nil, expectedError
+			}
+			deleted = append(deleted, serviceAccountID)
+			return &client.DeletedServiceAccountResponse{ID: serviceAccountID, Deleted: true}, nil
+		},
+	}
+
+	// Create management
+	management := NewManagement(mockClient, expiration, nil)
+
+	// Test CleanupAPIKey
+	report, err := management.CleanupAPIKey(context.Background(), "test-project", false)
+
+	// Verify result: the report lists the deletion made before the failure
+	if !errors.Is(err, expectedError) {
+		t.Errorf("Expected error to be %v, got %v", expectedError, err)
+	}
+	if report == nil {
+		t.Fatal("Expected a partial report, got nil")
+	}
+	if len(report.Entries) != 1 || report.Entries[0].ServiceAccountID != "sa_first" {
+		t.Errorf("Expected report to list sa_first, got %+v", report.Entries)
+	}
+}
+
+func TestCleanupAPIKey_PartialReportStoreError(t *testing.T) {
+	// Test data
+	expiration := 24 * time.Hour
+	oldTime := time.Now().Add(-2 * expiration)
+	expectedError := errors.New("store error")
+
+	// Create mock client
+	mockClient := &MockClient{
+		GetProjectFunc: func(ctx context.Context, name string) (*client.Project, bool, error) {
+			return &client.Project{ID: "proj_123", Name: name}, true, nil
+		},
+		ListServiceAccountsFunc: func(ctx context.Context, projID string) (*[]client.ServiceAccount, error) {
+			return &[]client.ServiceAccount{
+				{ID: "sa_old", Name: keyName{Owner: "old@example.com", ExpiresAt: oldTime.Add(expiration)}.String(), CreatedAt: oldTime.Unix()},
+			}, nil
+		},
+		DeleteServiceAccountFunc: func(ctx context.Context, projID string, serviceAccountID string) (*client.DeletedServiceAccountResponse, error) {
+			return &client.DeletedServiceAccountResponse{ID: serviceAccountID, Deleted: true}, nil
+		},
+	}
+
+	// Create management
+	management := NewManagement(mockClient, expiration, &readOnlyStore{Store: store.NewMemoryStore(), err: expectedError})
+
+	// Test CleanupAPIKey
+	report, err := management.CleanupAPIKey(context.Background(), "test-project", false)
+
+	// Verify result: the report lists the service account deleted before the store failed
+	if !errors.Is(err, expectedError) {
+		t.Errorf("Expected error to be %v, got %v", expectedError, err)
+	}
+	if report == nil {
+		t.Fatal("Expected a partial report, got nil")
+	}
+	if len(report.Entries) != 1 || report.Entries[0].ServiceAccountID != "sa_old" {
+		t.Errorf("Expected report to list sa_old, got %+v", report.Entries)
+	}
+}
+
+func TestGetAPIKey(t *testing.T) {
+	// Test data
+	keyStore := store.NewMemoryStore()
+	err := keyStore.Update(context.Background(), func(tx store.Tx) error {
+		return tx.Put(store.Key{OwnerEmail: "user@example.com", ProjectID: "proj_123", ServiceAccountID: "sa_123", KeyID: "key_123", IssuedAt: time.Now()})
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// Create mock client
+	mockClient := &MockClient{
+		GetServiceAccountFunc: func(ctx context.Context, projectID string, serviceAccountID string, keyID string) (*client.ServiceAccount, error) {
+			if projectID != "proj_123" || serviceAccountID != "sa_123" || keyID != "key_123" {
+				t.Errorf("Unexpected lookup of %s/%s with key %s", projectID, serviceAccountID, keyID)
+			}
+			return &client.ServiceAccount{ID: serviceAccountID, APIKey: client.ServiceAccountAPIKey{ID: keyID, RedactedValue: "sk-...abcd"}}, nil
+		},
+	}
+
+	// Create management
+	management := NewManagement(mockClient, 24*time.Hour, keyStore)
+
+	// Test GetAPIKey
+	serviceAccount, err := management.GetAPIKey(context.Background(), "sa_123")
+
+	// Verify result
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if serviceAccount.APIKey.ID != "key_123" || serviceAccount.APIKey.RedactedValue != "sk-...abcd" {
+		t.Errorf("Expected key key_123 with its redacted value, got %+v", serviceAccount.APIKey)
+	}
+
+	// Keys missing from the inventory are not looked up
+	if _, err := management.GetAPIKey(context.Background(), "sa_unknown"); !errors.Is(err, ErrKeyNotRecorded) {
+		t.Errorf("Expected error to be %v, got %v", ErrKeyNotRecorded, err)
+	}
+}
+
+func TestKeyTTL(t *testing.T) {
+	// Create management
+	management := NewManagement(&MockClient{}, 24*time.Hour, nil)
+	management.SetTTLLimits(time.Hour, 72*time.Hour)
+
+	// Verify result
+	tests := []struct {
+		requested time.Duration
+		expected  time.Duration
+	}{
+		{0, 24 * time.Hour},
+		{8 * time.Hour, 8 * time.Hour},
+		{10 * time.Minute, time.Hour},
+		{7 * 24 * time.Hour, 72 * time.Hour},
+	}
+	for _, tt := range tests {
+		if got := management.keyTTL(tt.requested); got != tt.expected {
+			t.Errorf("keyTTL(%v) = %v, want %v", tt.requested, got, tt.expected)
+		}
+	}
+
+	// Without limits only the default expiration is allowed
+	if got := NewManagement(&MockClient{}, 24*time.Hour, nil).keyTTL(time.Hour); got != 24*time.Hour {
+		t.Errorf("Expected the default expiration without limits, got %v", got)
+	}
+}
+
+func TestCreateAPIKey_RequestedTTL(t *testing.T) {
+	// Create mock client
+	mockClient := &MockClient{
+		GetProjectFunc: func(ctx context.Context, name string) (*client.Project, bool, error) {
+			return &client.Project{ID: "proj_123", Name: name}, true, nil
+		},
+		CreateServiceAccountFunc: func(ctx context.Context, projID string, name string) (*client.ServiceAccount, error) {
+			return &client.ServiceAccount{ID: "sa_123", Name: name, APIKey: client.ServiceAccountAPIKey{Value: "sk-test-key"}}, nil
+		},
+	}
+
+	// Create management
+	management := NewManagement(mockClient, 24*time.Hour, nil)
+	management.SetTTLLimits(time.Hour, 72*time.Hour)
+
+	// Test CreateAPIKey
+	_, expiresAt, err := management.CreateAPIKey(context.Background(), "test-project", "user@example.com", 7*24*time.Hour)
+
+	// Verify result: the request is clamped to the maximum and recorded
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	keys, err := management.ListAPIKeys(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	key := (*keys)[0]
+	if ttl := key.ExpiresAt.Sub(key.IssuedAt); ttl != 72*time.Hour {
+		t.Errorf("Expected a TTL of %v, got %v", 72*time.Hour, ttl)
+	}
+	if !expiresAt.Equal(key.ExpiresAt) {
+		t.Errorf("Expected expiration %v to match the recorded %v", *expiresAt, key.ExpiresAt)
+	}
+}
+
+func TestCleanupAPIKey_PerKeyExpiry(t *testing.T) {
+	// Test data: a short-lived key past its expiry, a long-lived key older than the default expiration
+	// and an old service account that was not created by this server
+	projectID := "proj_123"
+	expiration := 24 * time.Hour
+	now := time.Now()
+	keyStore := store.NewMemoryStore()
+	err := keyStore.Update(context.Background(), func(tx store.Tx) error {
+		if err := tx.Put(store.Key{ProjectID: projectID, ServiceAccountID: "sa_short", IssuedAt: now.Add(-2 * time.Hour), ExpiresAt: now.Add(-1 * time.Hour)}); err != nil {
+			return err
+		}
+		return tx.Put(store.Key{ProjectID: projectID, ServiceAccountID: "sa_long", IssuedAt: now.Add(-2 * expiration), ExpiresAt: now.Add(expiration)})
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	var deleted []string
+
+	// Create mock client
+	mockClient := &MockClient{
+		GetProjectFunc: func(ctx context.Context, name string) (*client.Project, bool, error) {
+			return &client.Project{ID: projectID, Name: name}, true, nil
+		},
+		ListServiceAccountsFunc: func(ctx context.Context, projID string) (*[]client.ServiceAccount, error) {
+			return &[]client.ServiceAccount{
+				{ID: "sa_short", CreatedAt: now.Add(-2 * time.Hour).Unix()},
+				{ID: "sa_long", CreatedAt: now.Add(-2 * expiration).Unix()},
+				{ID: "sa_manual", Name: "production-workload", CreatedAt: now.Add(-2 * expiration).Unix()},
+			}, nil
+		},
+		DeleteServiceAccountFunc: func(ctx context.Context, projID string, serviceAccountID string) (*client.DeletedServiceAccountResponse, error) {
+			deleted = append(deleted, serviceAccountID)
+			return &client.DeletedServiceAccountResponse{ID: serviceAccountID, Deleted: true}, nil
+		},
+	}
+
+	// Create management
+	management := NewManagement(mockClient, expiration, keyStore)
+
+	// Test CleanupAPIKey
+	if _, err := management.CleanupAPIKey(context.Background(), "test-project", false); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// Verify result: recorded expiries win and unrecorded service accounts are left alone
+	expected := []string{"sa_short"}
+	if !slices.Equal(deleted, expected) {
+		t.Errorf("Expected deleted service accounts %v, got %v", expected, deleted)
+	}
+}
+
+func TestCleanupAPIKey_DryRun(t *testing.T) {
+	// Test data
+	projectID := "proj_123"
+	expiration := 24 * time.Hour
+	now := time.Now()
+	keyStore := store.NewMemoryStore()
+	err := keyStore.Update(context.Background(), func(tx store.Tx) error {
+		return tx.Put(store.Key{OwnerEmail: "recorded@example.com", ProjectID: projectID, ServiceAccountID: "sa_recorded", IssuedAt: now.Add(-2 * expiration), ExpiresAt: now.Add(-1 * expiration)})
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// Create mock client
+	mockClient := &MockClient{
+		GetProjectFunc: func(ctx context.Context, name string) (*client.Project, bool, error) {
+			return &client.Project{ID: projectID, Name: name}, true, nil
+		},
+		ListServiceAccountsFunc: func(ctx context.Context, projID string) (*[]client.ServiceAccount, error) {
+			return &[]client.ServiceAccount{
+				{ID: "sa_named", Name: keyName{Owner: "named@example.com", ExpiresAt: now.Add(-1 * time.Hour)}.String(), CreatedAt: now.Add(-3 * time.Hour).Unix()},
+				{ID: "sa_recorded", Name: "recorded@example.com", CreatedAt: now.Add(-2 * expiration).Unix()},
+				{ID: "sa_fresh", Name: keyName{Owner: "fresh@example.com", ExpiresAt: now.Add(time.Hour)}.String(), CreatedAt: now.Unix()},
+			}, nil
+		},
+		DeleteServiceAccountFunc: func(ctx context.Context, projID string, serviceAccountID string) (*client.DeletedServiceAccountResponse, error) {
+			t.Errorf("Expected no deletion in a dry run, got %s", serviceAccountID)
+			return &client.DeletedServiceAccountResponse{ID: serviceAccountID, Deleted: true}, nil
+		},
+	}
+
+	// Create management
+	management := NewManagement(mockClient, expiration, keyStore)
+
+	// Test CleanupAPIKey
+	report, err := management.CleanupAPIKey(context.Background(), "test-project", true)
+
+	// Verify result
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !report.DryRun || report.ProjectID != projectID || len(report.Entries) != 2 {
+		t.Fatalf("Expected a dry-run report with 2 entries, got %+v", report)
+	}
+	named, recorded := report.Entries[0], report.Entries[1]
+	if named.ServiceAccountID != "sa_named" || named.Owner != "named@example.com" || named.Reason != CleanupReasonNameExpiry {
+		t.Errorf("Unexpected entry: %+v", named)
+	}
+	if named.Age < 3*time.Hour || named.Age > 3*time.Hour+time.Minute {
+		t.Errorf("Expected an age of about 3h, got %v", named.Age)
+	}
+	if recorded.ServiceAccountID != "sa_recorded" || recorded.Owner != "recorded@example.com" || recorded.Reason != CleanupReasonRecordedExpiry {
+		t.Errorf("Unexpected entry: %+v", recorded)
+	}
+
+	// The inventory is left untouched
+	keys, err := management.ListAPIKeys(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(*keys) != 1 {
+		t.Errorf("Expected 1 recorded key, got %d", len(*keys))
+	}
+}
+
+func TestCleanupAPIKey_LegacyNames(t *testing.T) {
+	// Test data: keys issued before names carried the expiry, and a hand-made service account
+	projectID := "proj_123"
+	expiration := 24 * time.Hour
+	now := time.Now()
+	var deleted []string
+
+	// Create mock client
+	mockClient := &MockClient{
+		GetProjectFunc: func(ctx context.Context, name string) (*client.Project, bool, error) {
+			return &client.Project{ID: projectID, Name: name}, true, nil
+		},
+		ListServiceAccountsFunc: func(ctx context.Context, projID string) (*[]client.ServiceAccount, error) {
+			return &[]client.ServiceAccount{
+				{ID: "sa_legacy_old", Name: "user@example.com", CreatedAt: now.Add(-2 * expiration).Unix()},
+				{ID: "sa_legacy_new", Name: "user@example.com", CreatedAt: now.Add(-1 * time.Hour).Unix()},
+				{ID: "sa_legacy_domain", Name: "someone@corp.example", CreatedAt: now.Add(-2 * expiration).Unix()},
+				{ID: "sa_other_email", Name: "other@example.com", CreatedAt: now.Add(-2 * expiration).Unix()},
+				{ID: "sa_manual", Name: "production-workload", CreatedAt: now.Add(-2 * expiration).Unix()},
+			}, nil
+		},
+		DeleteServiceAccountFunc: func(ctx context.Context, projID string, serviceAccountID string) (*client.DeletedServiceAccountResponse, error) {
+			deleted = append(deleted, serviceAccountID)
+			return &client.DeletedServiceAccountResponse{ID: serviceAccountID, Deleted: true}, nil
+		},
+	}
+
+	// Create management
+	management := NewManagement(mockClient, expiration, nil)
+	management.SetLegacyOwners(&[]string{"user@example.com"}, &[]string{"corp.example"})
+
+	// Test CleanupAPIKey
+	report, err := management.CleanupAPIKey(context.Background(), "test-project", false)
+
+	// Verify result: legacy keys of allowed owners expire after the default expiration
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := []string{"sa_legacy_old", "sa_legacy_domain"}
+	if !slices.Equal(deleted, expected) {
+		t.Errorf("Expected deleted service accounts %v, got %v", expected, deleted)
+	}
+	if len(report.Entries) != 2 || report.Entries[0].Reason != CleanupReasonLegacyExpiry || report.Entries[0].Owner != "user@example.com" {
+		t.Errorf("Unexpected report: %+v", report)
+	}
+}
@@ -0,0 +1,67 @@
+package management
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Service accounts created by this server are named "oaks1.<expiry>.<owner>": the server marker
+// followed by the format version, the expiry of the key in base-36 Unix seconds, and the owner's email.
+// Carrying the expiry in the name lets cleanup enforce per-key lifetimes without the key store.
+const (
+	keyNameMarker  = "oaks"
+	keyNameVersion = 1
+)
+
+// keyName is the issuance metadata encoded in the name of a service account.
+type keyName struct {
+	Owner     string
+	ExpiresAt time.Time
+}
+
+// String encodes the metadata as a service account name.
+func (n keyName) String() string {
+	return fmt.Sprintf("%s%d.%s.%s", keyNameMarker, keyNameVersion, strconv.FormatInt(n.ExpiresAt.Unix(), 36), n.Owner)
+}
+
+// legacyOwners identifies service accounts issued before names carried the expiry, which were
+// named with the bare email of an allowed user.
+type legacyOwners struct {
+	users   *[]string
+	domains *[]string
+}
+
+// owns reports whether name is the bare email of an allowed user or of a user in an allowed domain.
+func (o legacyOwners) owns(name string) bool {
+	local, domain, ok := strings.Cut(name, "@")
+	if !ok || local == "" || domain == "" || strings.ContainsAny(name, " \t") || strings.Contains(domain, "@") {
+		return false
+	}
+	if o.users != nil && slices.Contains(*o.users, name) {
+		return true
+	}
+	return o.domains != nil && slices.Contains(*o.domains, domain)
+}
+
+// parseKeyName decodes the metadata from the name of a service account created by this server.
+func parseKeyName(name string) (keyName, error) {
+	fields := strings.SplitN(name, ".", 3)
+	if len(fields) != 3 || !strings.HasPrefix(fields[0], keyNameMarker) {
+		return keyName{}, fmt.Errorf("parse key name %q: missing server marker", name)
+	}
+	// Compare the marker exactly: Atoi would also accept spellings such as "oaks+1" or "oaks01".
+	if fields[0] != keyNameMarker+strconv.Itoa(keyNameVersion) {
+		return keyName{}, fmt.Errorf("parse key name %q: unsupported version %q", name, strings.TrimPrefix(fields[0], keyNameMarker))
+	}
+	expiresAt, err := strconv.ParseInt(fields[1], 36, 64)
+	if err != nil {
+		return keyName{}, fmt.Errorf("parse key name %q: invalid expiry: %w", name, err)
+	}
+	if fields[2] == "" {
+		return keyName{}, fmt.Errorf("parse key name %q: missing owner", name)
+	}
+	return keyName{Owner: fields[2], ExpiresAt: time.Unix(expiresAt, 0)}, nil
+}
@@ -0,0 +1,77 @@
+package management
+
+import (
+	"testing"
+	"time"
+)
+
+func TestKeyName(t *testing.T) {
+	// Test data
+	name := keyName{Owner: "first.last@example.com", ExpiresAt: time.Unix(1792227600, 0)}
+
+	// Test String
+	encoded := name.String()
+	if encoded != "oaks1.tn1no0.first.last@example.com" {
+		t.Errorf("Expected encoded name 'oaks1.tn1no0.first.last@example.com', got '%s'", encoded)
+	}
+
+	// Test parseKeyName
+	decoded, err := parseKeyName(encoded)
+
+	// Verify result
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if decoded.Owner != name.Owner || !decoded.ExpiresAt.Equal(name.ExpiresAt) {
+		t.Errorf("Expected %+v, got %+v", name, decoded)
+	}
+}
+
+func TestParseKeyName_Invalid(t *testing.T) {
+	tests := []string{
+		"user@example.com",
+		"oaks.tn1no0.user@example.com",
+		"oaks2.tn1no0.user@example.com",
+		"oaks+1.tn1no0.user@example.com",
+		"oaks01.tn1no0.user@example.com",
+		"oaks1 .tn1no0.user@example.com",
+		"oaks1.not-base36.user@example.com",
+		"oaks1.tn1no0.",
+		"other1.tn1no0.user@example.com",
+	}
+	for _, name := range tests {
+		if _, err := parseKeyName(name); err == nil {
+			t.Errorf("parseKeyName(%q): expected error, got nil", name)
+		}
+	}
+}
+
+func TestLegacyOwners(t *testing.T) {
+	// Test data
+	owners := legacyOwners{users: &[]string{"user@example.com"}, domains: &[]string{"corp.example"}}
+
+	// Verify result
+	tests := []struct {
+		name     string
+		expected bool
+	}{
+		{"user@example.com", true},
+		{"someone@corp.example", true},
+		{"other@example.com", false},
+		{"production-workload", false},
+		{"@corp.example", false},
+		{"a@b@corp.example", false},
+		{"batch job@corp.example", false},
+		{"oaks1.tn1no0.user@example.com", false},
+	}
+	for _, tt := range tests {
+		if got := owners.owns(tt.name); got != tt.expected {
+			t.Errorf("owns(%q) = %v, want %v", tt.name, got, tt.expected)
+		}
+	}
+
+	// Without allowed users or domains no name is legacy
+	if (legacyOwners{}).owns("user@example.com") {
+		t.Error("Expected no legacy owners by default")
+	}
+}
@@ -0,0 +1,28 @@
+package management
+
+import "time"
+
+// CleanupReason explains why cleanup selected a service account.
+type CleanupReason string
+
+const (
+	CleanupReasonNameExpiry     CleanupReason = "expiry encoded in service account name has passed"
+	CleanupReasonRecordedExpiry CleanupReason = "expiry recorded in key inventory has passed"
+	CleanupReasonLegacyExpiry   CleanupReason = "legacy key is older than the default expiration"
+)
+
+// CleanupEntry describes a service account selected by cleanup.
+type CleanupEntry struct {
+	ServiceAccountID string
+	Owner            string
+	Age              time.Duration // Time since the service account was created
+	ExpiresAt        time.Time
+	Reason           CleanupReason
+}
+
+// CleanupReport lists the service accounts that a cleanup run deleted or, in a dry run, would delete.
+type CleanupReport struct {
+	ProjectID string
+	DryRun    bool
+	Entries   []CleanupEntry
+}
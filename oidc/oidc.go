@@ -3,6 +3,7 @@ package oidc
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"slices"
 	"strings"
 
@@ -35,6 +36,7 @@ type OIDC struct {
 	allowedDomains       *[]string // List of allowed email domains
 	googleTokenIssuerURL string    // Google token issuer URL
 	googleTokenJwksURL   string    // Google token JWKS URL
+	httpClient           *http.Client
 }
 
 // NewOIDC creates a new OIDC client with the specified configuration.
@@ -48,6 +50,19 @@ func NewOIDC(defaultProjectName string, allowedUsers *[]string, allowedDomains *
 	}
 }
 
+// SetHTTPClient sets the HTTP client used for token exchange and JWKS fetches.
+func (o *OIDC) SetHTTPClient(httpClient *http.Client) {
+	o.httpClient = httpClient
+}
+
+// ClientContext returns a context that makes go-oidc and oauth2 use the configured HTTP client.
+func (o *OIDC) ClientContext(ctx context.Context) context.Context {
+	if o.httpClient == nil {
+		return ctx
+	}
+	return oidc.ClientContext(ctx, o.httpClient)
+}
+
 // GetDefaultProjectName returns the configured default project name.
 func (o *OIDC) GetDefaultProjectName() string {
 	return o.defaultProjectName
@@ -100,7 +115,7 @@ func (o *OIDC) ExtractGoogleIDToken(ctx context.Context, aud string, idToken str
 	verifier := createTokenVerifier(o.googleTokenIssuerURL, o.googleTokenJwksURL)
 
 	// Verify token
-	claims, err := verifier.VerifyToken(ctx, aud, idToken)
+	claims, err := verifier.VerifyToken(o.ClientContext(ctx), aud, idToken)
 	if err != nil {
 		return "", "", fmt.Errorf("verify id token: %w", err)
 	}
@@ -3,7 +3,10 @@ package oidc
 import (
 	"context"
 	"errors"
+	"net/http"
 	"testing"
+
+	"golang.org/x/oauth2"
 )
 
 func TestNewOIDC(t *testing.T) {
@@ -231,3 +234,36 @@ func TestExtractGoogleIDToken_Success(t *testing.T) {
 		t.Errorf("Expected email 'user1@example.com', got '%s'", email)
 	}
 }
+
+func TestExtractGoogleIDToken_HTTPClient(t *testing.T) {
+	// Create OIDC client with a custom HTTP client
+	httpClient := &http.Client{}
+	oidcClient := &OIDC{
+		defaultProjectName: "test-project",
+		allowedUsers:       &[]string{"user1@example.com"},
+		allowedDomains:     &[]string{},
+	}
+	oidcClient.SetHTTPClient(httpClient)
+
+	// Setup mock verifier that checks the context carries the HTTP client
+	cleanup := setupTokenVerifierTest(func(ctx context.Context, aud string, idToken string) (*GoogleIDTokenClaims, error) {
+		if c, _ := ctx.Value(oauth2.HTTPClient).(*http.Client); c != httpClient {
+			t.Errorf("Expected verifier context to carry the configured HTTP client, got %v", c)
+		}
+		return &GoogleIDTokenClaims{Email: "user1@example.com", EmailVerified: true}, nil
+	})
+	defer cleanup()
+
+	// Test ExtractGoogleIDToken
+	if _, _, err := oidcClient.ExtractGoogleIDToken(context.Background(), "client-id", "fake-token"); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
+
+func TestClientContext_NoHTTPClient(t *testing.T) {
+	oidcClient := &OIDC{}
+	ctx := context.Background()
+	if got := oidcClient.ClientContext(ctx); got != ctx {
+		t.Error("Expected context to be unchanged without an HTTP client")
+	}
+}
@@ -0,0 +1,607 @@
+// Package openaitest provides an in-memory fake of the OpenAI Admin API for tests.
+//
+// The fake covers the project, service account and project API key endpoints used by client.Client,
+// including has_more/after pagination, archived projects and injectable errors:
+//
+//	srv := openaitest.NewServer()
+//	defer srv.Close()
+//	c := client.NewClient(openaitest.APIKey, srv.Client())
+//	c.BaseURL = srv.BaseURL()
+package openaitest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// APIKey is the admin key accepted by the fake server.
+const APIKey = "sk-admin-openaitest"
+
+// DefaultPageSize is the page size used when a list request has no limit parameter.
+const DefaultPageSize = 20
+
+// Project is a project stored by the fake server.
+type Project struct {
+	ID         string `json:"id"`
+	Object     string `json:"object"`
+	Name       string `json:"name"`
+	CreatedAt  int64  `json:"created_at"`
+	ArchivedAt *int64 `json:"archived_at"`
+	Status     string `json:"status"`
+}
+
+// ServiceAccount is a service account stored by the fake server.
+type ServiceAccount struct {
+	ID        string               `json:"id"`
+	Object    string               `json:"object"`
+	Name      string               `json:"name"`
+	Role      string               `json:"role"`
+	CreatedAt int64                `json:"created_at"`
+	APIKey    ServiceAccountAPIKey `json:"-"` // Key issued on creation; only returned by the create endpoint
+}
+
+// ServiceAccountAPIKey is the API key embedded in a service account creation response.
+type ServiceAccountAPIKey struct {
+	Object    string `json:"object"`
+	Value     string `json:"value"`
+	Name      string `json:"name"`
+	CreatedAt int64  `json:"created_at"`
+	ID        string `json:"id"`
+}
+
+// Fault describes an error response injected into matching requests.
+type Fault struct {
+	Method     string        // HTTP method to match; empty matches any method
+	Path       string        // path.Match pattern relative to the organization base, e.g. "/projects/*/service_accounts"
+	Status     int           // HTTP status code to return
+	Code       string        // Error code in the error envelope
+	Message    string        // Error message in the error envelope; defaults to the status text
+	RetryAfter time.Duration // Value of the Retry-After header, if positive
+	Times      int           // Number of requests to fail; zero fails every matching request
+}
+
+// Request records a request received by the fake server.
+type Request struct {
+	Method string
+	Path   string // Path relative to the organization base
+	Query  string
+}
+
+// Server is an in-memory fake of the OpenAI Admin API.
+type Server struct {
+	*httptest.Server
+
+	mu              sync.Mutex
+	now             func() time.Time
+	nextID          int
+	projects        []*Project
+	serviceAccounts map[string][]*ServiceAccount
+	faults          []*Fault
+	requests        []Request
+}
+
+// NewServer starts a fake Admin API server. The caller must call Close when finished.
+func NewServer() *Server {
+	s := &Server{
+		now:             time.Now,
+		serviceAccounts: make(map[string][]*ServiceAccount),
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// BaseURL returns the organization base URL to assign to client.Client.BaseURL.
+func (s *Server) BaseURL() string {
+	return s.URL + "/v1/organization"
+}
+
+// SetNow overrides the clock used for created_at and archived_at timestamps.
+func (s *Server) SetNow(now func() time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.now = now
+}
+
+// AddProject stores an active project and returns it.
+func (s *Server) AddProject(name string) Project {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return *s.addProject(name)
+}
+
+// ArchiveProject marks the project as archived. It reports false if the project does not exist.
+func (s *Server) ArchiveProject(projectID string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	project := s.findProject(projectID)
+	if project == nil {
+		return false
+	}
+	s.archive(project)
+	return true
+}
+
+// Projects returns a snapshot of all stored projects, including archived ones.
+func (s *Server) Projects() []Project {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	projects := make([]Project, 0, len(s.projects))
+	for _, project := range s.projects {
+		projects = append(projects, *project)
+	}
+	return projects
+}
+
+// AddServiceAccount stores a service account created at the given time and returns it.
+func (s *Server) AddServiceAccount(projectID string, name string, createdAt time.Time) ServiceAccount {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return *s.addServiceAccount(projectID, name, createdAt)
+}
+
+// ServiceAccounts returns a snapshot of the service accounts stored for a project.
+func (s *Server) ServiceAccounts(projectID string) []ServiceAccount {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	serviceAccounts := make([]ServiceAccount, 0, len(s.serviceAccounts[projectID]))
+	for _, serviceAccount := range s.serviceAccounts[projectID] {
+		serviceAccounts = append(serviceAccounts, *serviceAccount)
+	}
+	return serviceAccounts
+}
+
+// InjectFault makes matching requests fail with the fault's error until it is used up or cleared.
+func (s *Server) InjectFault(fault Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = append(s.faults, &fault)
+}
+
+// ClearFaults removes all injected faults.
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = nil
+}
+
+// Requests returns the requests received so far.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.requests)
+}
+
+// serveHTTP authenticates, applies injected faults and routes the request.
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p, ok := strings.CutPrefix(r.URL.Path, "/v1/organization")
+	if !ok {
+		writeError(w, http.StatusNotFound, "", "Unknown endpoint")
+		return
+	}
+	s.requests = append(s.requests, Request{Method: r.Method, Path: p, Query: r.URL.RawQuery})
+
+	if r.Header.Get("Authorization") != "Bearer "+APIKey {
+		writeError(w, http.StatusUnauthorized, "invalid_api_key", "Incorrect API key provided")
+		return
+	}
+	if fault := s.matchFault(r.Method, p); fault != nil {
+		if fault.RetryAfter > 0 {
+			w.Header().Set("Retry-After", strconv.Itoa(int(fault.RetryAfter.Seconds())))
+		}
+		message := fault.Message
+		if message == "" {
+			message = http.StatusText(fault.Status)
+		}
+		writeError(w, fault.Status, fault.Code, message)
+		return
+	}
+
+	segments := strings.Split(strings.Trim(p, "/"), "/")
+	switch {
+	case len(segments) == 1 && segments[0] == "projects":
+		switch r.Method {
+		case http.MethodGet:
+			s.listProjects(w, r)
+		case http.MethodPost:
+			s.createProject(w, r)
+		default:
+			writeMethodNotAllowed(w)
+		}
+	case len(segments) == 2 && segments[0] == "projects":
+		switch r.Method {
+		case http.MethodGet:
+			s.getProject(w, segments[1])
+		case http.MethodPost:
+			s.modifyProject(w, r, segments[1])
+		default:
+			writeMethodNotAllowed(w)
+		}
+	case len(segments) == 3 && segments[0] == "projects" && segments[2] == "archive":
+		if r.Method != http.MethodPost {
+			writeMethodNotAllowed(w)
+			return
+		}
+		s.archiveProject(w, segments[1])
+	case len(segments) == 3 && segments[0] == "projects" && segments[2] == "service_accounts":
+		switch r.Method {
+		case http.MethodGet:
+			s.listServiceAccounts(w, r, segments[1])
+		case http.MethodPost:
+			s.createServiceAccount(w, r, segments[1])
+		default:
+			writeMethodNotAllowed(w)
+		}
+	case len(segments) == 3 && segments[0] == "projects" && segments[2] == "api_keys":
+		if r.Method != http.MethodGet {
+			writeMethodNotAllowed(w)
+			return
+		}
+		s.listProjectAPIKeys(w, r, segments[1])
+	case len(segments) == 4 && segments[0] == "projects" && segments[2] == "api_keys":
+		if r.Method != http.MethodGet {
+			writeMethodNotAllowed(w)
+			return
+		}
+		s.getProjectAPIKey(w, segments[1], segments[3])
+	case len(segments) == 4 && segments[0] == "projects" && segments[2] == "service_accounts":
+		switch r.Method {
+		case http.MethodGet:
+			s.getServiceAccount(w, segments[1], segments[3])
+		case http.MethodDelete:
+			s.deleteServiceAccount(w, segments[1], segments[3])
+		default:
+			writeMethodNotAllowed(w)
+		}
+	default:
+		writeError(w, http.StatusNotFound, "", "Unknown endpoint")
+	}
+}
+
+// matchFault returns the first injected fault matching the request, consuming one use of it.
+func (s *Server) matchFault(method string, p string) *Fault {
+	for i, fault := range s.faults {
+		if fault.Method != "" && fault.Method != method {
+			continue
+		}
+		if matched, _ := path.Match(fault.Path, p); !matched {
+			continue
+		}
+		if fault.Times > 0 {
+			fault.Times--
+			if fault.Times == 0 {
+				s.faults = slices.Delete(s.faults, i, i+1)
+			}
+		}
+		return fault
+	}
+	return nil
+}
+
+func (s *Server) listProjects(w http.ResponseWriter, r *http.Request) {
+	includeArchived := r.URL.Query().Get("include_archived") == "true"
+	var projects []*Project
+	for _, project := range s.projects {
+		if includeArchived || project.ArchivedAt == nil {
+			projects = append(projects, project)
+		}
+	}
+	writePage(w, r, projects, func(p *Project) string { return p.ID })
+}
+
+func (s *Server) createProject(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Name string `json:"name"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body.Name == "" {
+		writeError(w, http.StatusBadRequest, "invalid_request", "A project name is required")
+		return
+	}
+	writeJSON(w, http.StatusOK, s.addProject(body.Name))
+}
+
+func (s *Server) getProject(w http.ResponseWriter, projectID string) {
+	project := s.findProject(projectID)
+	if project == nil {
+		writeProjectNotFound(w, projectID)
+		return
+	}
+	writeJSON(w, http.StatusOK, project)
+}
+
+func (s *Server) modifyProject(w http.ResponseWriter, r *http.Request, projectID string) {
+	project := s.findProject(projectID)
+	if project == nil {
+		writeProjectNotFound(w, projectID)
+		return
+	}
+	if project.ArchivedAt != nil {
+		writeProjectArchived(w, projectID)
+		return
+	}
+	var body struct {
+		Name string `json:"name"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body.Name == "" {
+		writeError(w, http.StatusBadRequest, "invalid_request", "A project name is required")
+		return
+	}
+	project.Name = body.Name
+	writeJSON(w, http.StatusOK, project)
+}
+
+func (s *Server) archiveProject(w http.ResponseWriter, projectID string) {
+	project := s.findProject(projectID)
+	if project == nil {
+		writeProjectNotFound(w, projectID)
+		return
+	}
+	if project.ArchivedAt == nil {
+		s.archive(project)
+	}
+	writeJSON(w, http.StatusOK, project)
+}
+
+func (s *Server) listServiceAccounts(w http.ResponseWriter, r *http.Request, projectID string) {
+	if s.findProject(projectID) == nil {
+		writeProjectNotFound(w, projectID)
+		return
+	}
+	writePage(w, r, s.serviceAccounts[projectID], func(sa *ServiceAccount) string { return sa.ID })
+}
+
+func (s *Server) createServiceAccount(w http.ResponseWriter, r *http.Request, projectID string) {
+	project := s.findProject(projectID)
+	if project == nil {
+		writeProjectNotFound(w, projectID)
+		return
+	}
+	if project.ArchivedAt != nil {
+		writeProjectArchived(w, projectID)
+		return
+	}
+	var body struct {
+		Name string `json:"name"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body.Name == "" {
+		writeError(w, http.StatusBadRequest, "invalid_request", "A service account name is required")
+		return
+	}
+	serviceAccount := s.addServiceAccount(projectID, body.Name, s.now())
+	writeJSON(w, http.StatusOK, struct {
+		*ServiceAccount
+		APIKey ServiceAccountAPIKey `json:"api_key"`
+	}{serviceAccount, serviceAccount.APIKey})
+}
+
+func (s *Server) getServiceAccount(w http.ResponseWriter, projectID string, serviceAccountID string) {
+	if s.findProject(projectID) == nil {
+		writeProjectNotFound(w, projectID)
+		return
+	}
+	for _, serviceAccount := range s.serviceAccounts[projectID] {
+		if serviceAccount.ID == serviceAccountID {
+			writeJSON(w, http.StatusOK, serviceAccount)
+			return
+		}
+	}
+	writeError(w, http.StatusNotFound, "", fmt.Sprintf("No such service account: %s", serviceAccountID))
+}
+
+func (s *Server) deleteServiceAccount(w http.ResponseWriter, projectID string, serviceAccountID string) {
+	if s.findProject(projectID) == nil {
+		writeProjectNotFound(w, projectID)
+		return
+	}
+	serviceAccounts := s.serviceAccounts[projectID]
+	for i, serviceAccount := range serviceAccounts {
+		if serviceAccount.ID == serviceAccountID {
+			s.serviceAccounts[projectID] = slices.Delete(serviceAccounts, i, i+1)
+			writeJSON(w, http.StatusOK, map[string]any{
+				"object":  "organization.project.service_account.deleted",
+				"id":      serviceAccountID,
+				"deleted": true,
+			})
+			return
+		}
+	}
+	writeError(w, http.StatusNotFound, "", fmt.Sprintf("No such service account: %s", serviceAccountID))
+}
+
+// projectAPIKey is a service account key as returned by the project API keys endpoint.
+type projectAPIKey struct {
+	Object        string `json:"object"`
+	ID            string `json:"id"`
+	Name          string `json:"name"`
+	RedactedValue string `json:"redacted_value"`
+	CreatedAt     int64  `json:"created_at"`
+	LastUsedAt    *int64 `json:"last_used_at"`
+	Owner         struct {
+		Type           string          `json:"type"`
+		ServiceAccount *ServiceAccount `json:"service_account"`
+	} `json:"owner"`
+}
+
+func (s *Server) listProjectAPIKeys(w http.ResponseWriter, r *http.Request, projectID string) {
+	if s.findProject(projectID) == nil {
+		writeProjectNotFound(w, projectID)
+		return
+	}
+	keys := make([]*projectAPIKey, 0, len(s.serviceAccounts[projectID]))
+	for _, serviceAccount := range s.serviceAccounts[projectID] {
+		keys = append(keys, newProjectAPIKey(serviceAccount))
+	}
+	writePage(w, r, keys, func(k *projectAPIKey) string { return k.ID })
+}
+
+func (s *Server) getProjectAPIKey(w http.ResponseWriter, projectID string, keyID string) {
+	if s.findProject(projectID) == nil {
+		writeProjectNotFound(w, projectID)
+		return
+	}
+	for _, serviceAccount := range s.serviceAccounts[projectID] {
+		if serviceAccount.APIKey.ID == keyID {
+			writeJSON(w, http.StatusOK, newProjectAPIKey(serviceAccount))
+			return
+		}
+	}
+	writeError(w, http.StatusNotFound, "", fmt.Sprintf("No such API key: %s", keyID))
+}
+
+// newProjectAPIKey describes the key of a service account as the project API keys endpoints return it.
+func newProjectAPIKey(serviceAccount *ServiceAccount) *projectAPIKey {
+	value := serviceAccount.APIKey.Value
+	key := &projectAPIKey{
+		Object:        "organization.project.api_key",
+		ID:            serviceAccount.APIKey.ID,
+		Name:          serviceAccount.APIKey.Name,
+		RedactedValue: value[:len("sk-svcacct-")] + "..." + value[len(value)-4:],
+		CreatedAt:     serviceAccount.APIKey.CreatedAt,
+	}
+	key.Owner.Type = "service_account"
+	key.Owner.ServiceAccount = serviceAccount
+	return key
+}
+
+func (s *Server) addProject(name string) *Project {
+	project := &Project{
+		ID:        s.newID("proj_"),
+		Object:    "organization.project",
+		Name:      name,
+		CreatedAt: s.now().Unix(),
+		Status:    "active",
+	}
+	s.projects = append(s.projects, project)
+	return project
+}
+
+func (s *Server) archive(project *Project) {
+	archivedAt := s.now().Unix()
+	project.ArchivedAt = &archivedAt
+	project.Status = "archived"
+}
+
+func (s *Server) addServiceAccount(projectID string, name string, createdAt time.Time) *ServiceAccount {
+	serviceAccount := &ServiceAccount{
+		ID:        s.newID("svc_acct_"),
+		Object:    "organization.project.service_account",
+		Name:      name,
+		Role:      "member",
+		CreatedAt: createdAt.Unix(),
+		APIKey: ServiceAccountAPIKey{
+			Object:    "organization.project.service_account.api_key",
+			Name:      "Secret Key",
+			CreatedAt: createdAt.Unix(),
+		},
+	}
+	serviceAccount.APIKey.ID = s.newID("key_")
+	serviceAccount.APIKey.Value = "sk-svcacct-" + serviceAccount.APIKey.ID
+	s.serviceAccounts[projectID] = append(s.serviceAccounts[projectID], serviceAccount)
+	return serviceAccount
+}
+
+func (s *Server) findProject(projectID string) *Project {
+	for _, project := range s.projects {
+		if project.ID == projectID {
+			return project
+		}
+	}
+	return nil
+}
+
+func (s *Server) newID(prefix string) string {
+	s.nextID++
+	return fmt.Sprintf("%s%06d", prefix, s.nextID)
+}
+
+// writePage writes one page of items following the limit and after query parameters.
+func writePage[T any](w http.ResponseWriter, r *http.Request, items []T, id func(T) string) {
+	limit := DefaultPageSize
+	if v := r.URL.Query().Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > 100 {
+			writeError(w, http.StatusBadRequest, "invalid_request", "limit must be between 1 and 100")
+			return
+		}
+		limit = n
+	}
+	start := 0
+	if after := r.URL.Query().Get("after"); after != "" {
+		i := slices.IndexFunc(items, func(item T) bool { return id(item) == after })
+		if i < 0 {
+			writeError(w, http.StatusBadRequest, "invalid_request", fmt.Sprintf("Invalid cursor: %s", after))
+			return
+		}
+		start = i + 1
+	}
+	end := min(start+limit, len(items))
+	page := items[start:end]
+
+	resp := struct {
+		Object  string  `json:"object"`
+		Data    []T     `json:"data"`
+		FirstID *string `json:"first_id"`
+		LastID  *string `json:"last_id"`
+		HasMore bool    `json:"has_more"`
+	}{
+		Object:  "list",
+		Data:    page,
+		HasMore: end < len(items),
+	}
+	if resp.Data == nil {
+		resp.Data = []T{}
+	}
+	if len(page) > 0 {
+		firstID, lastID := id(page[0]), id(page[len(page)-1])
+		resp.FirstID, resp.LastID = &firstID, &lastID
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func writeProjectNotFound(w http.ResponseWriter, projectID string) {
+	writeError(w, http.StatusNotFound, "", fmt.Sprintf("No such project: %s", projectID))
+}
+
+func writeProjectArchived(w http.ResponseWriter, projectID string) {
+	writeError(w, http.StatusBadRequest, "invalid_request", fmt.Sprintf("Project %s is archived", projectID))
+}
+
+func writeMethodNotAllowed(w http.ResponseWriter) {
+	writeError(w, http.StatusMethodNotAllowed, "", "Method not allowed")
+}
+
+// writeError writes an error in the OpenAI error envelope.
+func writeError(w http.ResponseWriter, status int, code string, message string) {
+	body := map[string]any{
+		"message": message,
+		"type":    "invalid_request_error",
+		"param":   nil,
+		"code":    nil,
+	}
+	if code != "" {
+		body["code"] = code
+	}
+	if status == http.StatusTooManyRequests && code == "" {
+		body["type"] = "rate_limit_exceeded"
+	}
+	if status >= 500 {
+		body["type"] = "server_error"
+	}
+	writeJSON(w, status, map[string]any{"error": body})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,189 @@
+package openaitest_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/hi120ki/monorepo/projects/openaikeyserver/client"
+	"github.com/hi120ki/monorepo/projects/openaikeyserver/openaitest"
+)
+
+func newClient(srv *openaitest.Server) *client.Client {
+	c := client.NewClient(openaitest.APIKey, srv.Client())
+	c.BaseURL = srv.BaseURL()
+	c.Retry = client.RetryPolicy{MaxRetries: 2, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond}
+	return c
+}
+
+func TestServer_ProjectLifecycle(t *testing.T) {
+	srv := openaitest.NewServer()
+	defer srv.Close()
+	c := newClient(srv)
+	ctx := context.Background()
+
+	// Create and look up a project
+	created, err := c.CreateProject(ctx, "test-project")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	project, found, err := c.GetProject(ctx, "test-project")
+	if err != nil || !found || project.ID != created.ID {
+		t.Fatalf("Expected project %s to be found, got %v (found: %v, err: %v)", created.ID, project, found, err)
+	}
+
+	// Archive it and check that it is reported as archived
+	if _, err := c.ArchiveProject(ctx, created.ID); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	project, found, err = c.GetProject(ctx, "test-project")
+	if err != nil || !found || !project.IsArchived() {
+		t.Errorf("Expected archived project to be found, got %v (found: %v, err: %v)", project, found, err)
+	}
+	if _, err := c.CreateServiceAccount(ctx, created.ID, "test-sa"); err == nil {
+		t.Error("Expected error creating a service account in an archived project, got nil")
+	}
+}
+
+func TestServer_ServiceAccounts(t *testing.T) {
+	srv := openaitest.NewServer()
+	defer srv.Close()
+	c := newClient(srv)
+	ctx := context.Background()
+	project := srv.AddProject("test-project")
+
+	// Created service accounts carry an API key
+	sa, err := c.CreateServiceAccount(ctx, project.ID, "test-sa")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if sa.APIKey.Value == "" || sa.APIKey.ID == "" {
+		t.Errorf("Expected service account to include an API key, got %+v", sa.APIKey)
+	}
+
+	// Listing spans several pages
+	for i := 0; i < 2*openaitest.DefaultPageSize+5; i++ {
+		srv.AddServiceAccount(project.ID, "seeded", time.Now())
+	}
+	serviceAccounts, err := c.ListServiceAccounts(ctx, project.ID)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(*serviceAccounts) != 2*openaitest.DefaultPageSize+6 {
+		t.Errorf("Expected %d service accounts, got %d", 2*openaitest.DefaultPageSize+6, len(*serviceAccounts))
+	}
+
+	// Deleted service accounts disappear
+	if _, err := c.DeleteServiceAccount(ctx, project.ID, sa.ID); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := c.DeleteServiceAccount(ctx, project.ID, sa.ID); !errors.Is(err, client.ErrNotFound) {
+		t.Errorf("Expected error to be %v, got %v", client.ErrNotFound, err)
+	}
+	if n := len(srv.ServiceAccounts(project.ID)); n != 2*openaitest.DefaultPageSize+5 {
+		t.Errorf("Expected %d service accounts, got %d", 2*openaitest.DefaultPageSize+5, n)
+	}
+}
+
+func TestServer_Faults(t *testing.T) {
+	srv := openaitest.NewServer()
+	defer srv.Close()
+	c := newClient(srv)
+	ctx := context.Background()
+
+	// A transient 503 is retried away
+	srv.InjectFault(openaitest.Fault{Method: http.MethodGet, Path: "/projects", Status: http.StatusServiceUnavailable, Times: 1})
+	if _, _, err := c.GetProject(ctx, "test-project"); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+
+	// A persistent 429 surfaces as a rate limit error
+	srv.InjectFault(openaitest.Fault{Path: "/projects", Status: http.StatusTooManyRequests})
+	if _, _, err := c.GetProject(ctx, "test-project"); !errors.Is(err, client.ErrRateLimited) {
+		t.Errorf("Expected error to be %v, got %v", client.ErrRateLimited, err)
+	}
+	srv.ClearFaults()
+
+	// Quota errors are reported through the error code
+	srv.InjectFault(openaitest.Fault{Method: http.MethodPost, Path: "/projects", Status: http.StatusTooManyRequests, Code: "insufficient_quota", Times: 1})
+	if _, err := c.CreateProject(ctx, "test-project"); !errors.Is(err, client.ErrQuotaExceeded) {
+		t.Errorf("Expected error to be %v, got %v", client.ErrQuotaExceeded, err)
+	}
+
+	// A wrong admin key is rejected
+	c.APIKey = "sk-admin-wrong"
+	if _, _, err := c.GetProject(ctx, "test-project"); !errors.Is(err, client.ErrUnauthorized) {
+		t.Errorf("Expected error to be %v, got %v", client.ErrUnauthorized, err)
+	}
+}
+
+func TestServer_GetServiceAccount(t *testing.T) {
+	srv := openaitest.NewServer()
+	defer srv.Close()
+	c := newClient(srv)
+	project := srv.AddProject("test-project")
+	srv.AddServiceAccount(project.ID, "other@example.com", time.Now())
+	seeded := srv.AddServiceAccount(project.ID, "user@example.com", time.Now())
+
+	// Look up the service account and its key metadata
+	sa, err := c.GetServiceAccount(context.Background(), project.ID, seeded.ID, seeded.APIKey.ID)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if sa.Name != "user@example.com" || sa.APIKey.ID != seeded.APIKey.ID {
+		t.Errorf("Expected service account %s with key %s, got %+v", seeded.ID, seeded.APIKey.ID, sa)
+	}
+	if sa.APIKey.Value != "" || sa.APIKey.RedactedValue == "" || sa.APIKey.RedactedValue == seeded.APIKey.Value {
+		t.Errorf("Expected only the redacted key value, got %+v", sa.APIKey)
+	}
+
+	// Unknown service accounts are reported as not found
+	if _, err := c.GetServiceAccount(context.Background(), project.ID, "svc_acct_missing", ""); !errors.Is(err, client.ErrNotFound) {
+		t.Errorf("Expected error to be %v, got %v", client.ErrNotFound, err)
+	}
+
+	// Unknown keys are reported as not found
+	if _, err := c.GetServiceAccount(context.Background(), project.ID, seeded.ID, "key_missing"); !errors.Is(err, client.ErrNotFound) {
+		t.Errorf("Expected error to be %v, got %v", client.ErrNotFound, err)
+	}
+}
+
+func TestServer_NoSchemaDrift(t *testing.T) {
+	srv := openaitest.NewServer()
+	defer srv.Close()
+	c := newClient(srv)
+	c.Drift = client.NewDriftDetector(nil)
+	ctx := context.Background()
+
+	// Exercise every endpoint that strict decoding checks
+	project, err := c.CreateProject(ctx, "test-project")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, _, err := c.GetProject(ctx, "missing-project"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := c.ModifyProject(ctx, project.ID, "renamed-project"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	sa, err := c.CreateServiceAccount(ctx, project.ID, "user@example.com")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := c.GetServiceAccount(ctx, project.ID, sa.ID, sa.APIKey.ID); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := c.ListServiceAccounts(ctx, project.ID); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := c.ArchiveProject(ctx, project.ID); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// The fake matches the client types, so nothing drifted
+	if counts := c.Drift.Counts(); len(counts) != 0 {
+		t.Errorf("Expected no schema drift, got %v", counts)
+	}
+}
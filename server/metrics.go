@@ -0,0 +1,78 @@
+package server
+
+import (
+	"context"
+	"expvar"
+	"log/slog"
+	"sync"
+
+	"github.com/hi120ki/monorepo/projects/openaikeyserver/client"
+)
+
+// metrics holds the server's counters, published through expvar under "openaikeyserver".
+var metrics = expvar.NewMap("openaikeyserver")
+
+// circuitState holds the current state of the OpenAI circuit breaker.
+var circuitState = new(expvar.String)
+
+// openaiRequests holds per-endpoint statistics for OpenAI API requests, keyed by method and templated path.
+var openaiRequests = new(expvar.Map).Init()
+
+// schemaDrift counts differences between OpenAI API responses and the client types, keyed by kind and field.
+var schemaDrift = new(expvar.Map).Init()
+
+// openaiRequestsMu serializes the creation of per-endpoint maps in openaiRequests.
+var openaiRequestsMu sync.Mutex
+
+func init() {
+	circuitState.Set(client.BreakerClosed.String())
+	metrics.Set("circuit_state", circuitState)
+	metrics.Set("openai_requests", openaiRequests)
+	metrics.Set("openai_schema_drift", schemaDrift)
+}
+
+// recordBreakerStateChange logs circuit breaker transitions and exports them as metrics.
+func recordBreakerStateChange(from client.BreakerState, to client.BreakerState) {
+	circuitState.Set(to.String())
+	metrics.Add("circuit_state_changes", 1)
+	if to == client.BreakerOpen {
+		metrics.Add("circuit_opens", 1)
+		slog.Warn("openai circuit breaker opened", "from", from.String())
+		return
+	}
+	slog.Info("openai circuit breaker state changed", "from", from.String(), "to", to.String())
+}
+
+// requestObserver exports the statistics of every OpenAI API request as metrics.
+type requestObserver struct{}
+
+// ObserveRequest records the request count, latency, retries and errors for the request's endpoint.
+func (requestObserver) ObserveRequest(ctx context.Context, info client.RequestInfo) {
+	stats := endpointStats(info.Method + " " + info.Path)
+	stats.Add("requests", 1)
+	stats.Add("latency_ms_total", info.Latency.Milliseconds())
+	stats.Add("retries", int64(info.Retries))
+	if info.ErrorClass != "" {
+		stats.Add("errors", 1)
+		stats.Add("errors_"+info.ErrorClass, 1)
+	}
+	slog.DebugContext(ctx, "openai api request", "method", info.Method, "path", info.Path, "status", info.StatusCode,
+		"latency", info.Latency, "retries", info.Retries, "error_class", info.ErrorClass)
+}
+
+// endpointStats returns the statistics map for an endpoint, creating it on first use.
+func endpointStats(endpoint string) *expvar.Map {
+	openaiRequestsMu.Lock()
+	defer openaiRequestsMu.Unlock()
+	if stats, ok := openaiRequests.Get(endpoint).(*expvar.Map); ok {
+		return stats
+	}
+	stats := new(expvar.Map).Init()
+	openaiRequests.Set(endpoint, stats)
+	return stats
+}
+
+// recordDrift counts a schema drift found by strict decoding.
+func recordDrift(drift client.Drift) {
+	schemaDrift.Add(drift.Kind+" "+drift.Type+"."+drift.Field, 1)
+}
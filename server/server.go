@@ -2,6 +2,7 @@ package server
 
 import (
 	"context"
+	"expvar"
 	"fmt"
 	"log/slog"
 	"net/http"
@@ -15,6 +16,8 @@ import (
 	"github.com/hi120ki/monorepo/projects/openaikeyserver/handler"
 	"github.com/hi120ki/monorepo/projects/openaikeyserver/management"
 	"github.com/hi120ki/monorepo/projects/openaikeyserver/oidc"
+	"github.com/hi120ki/monorepo/projects/openaikeyserver/store"
+	"github.com/hi120ki/monorepo/projects/openaikeyserver/transport"
 )
 
 // Server handles HTTP requests and manages the application lifecycle.
@@ -24,21 +27,59 @@ type Server struct {
 	handler    *handler.Handler
 	management *management.Management
 	oidc       *oidc.OIDC
+	keyStore   store.Store
 	shutdown   chan struct{}
 }
 
 // NewServer initializes a new server with the provided configuration.
 func NewServer(cfg *config.Config) (*Server, error) {
+	httpClient, err := transport.NewHTTPClient(transport.Config{
+		ProxyURL:            cfg.GetOutboundProxyURL(),
+		CAFiles:             *cfg.GetOutboundCAFiles(),
+		ClientCertFile:      cfg.GetOutboundClientCertFile(),
+		ClientKeyFile:       cfg.GetOutboundClientKeyFile(),
+		MaxIdleConns:        cfg.GetOutboundMaxIdleConns(),
+		MaxIdleConnsPerHost: cfg.GetOutboundMaxIdleConnsPerHost(),
+		MaxConnsPerHost:     cfg.GetOutboundMaxConnsPerHost(),
+		IdleConnTimeout:     cfg.GetOutboundIdleConnTimeout(),
+		Timeout:             cfg.GetTimeout(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create http client: %w", err)
+	}
 	openaiClient := client.NewClient(
 		cfg.GetOpenAIManagementKey(),
-		&http.Client{
-			Timeout: cfg.GetTimeout(),
-		},
+		httpClient,
 	)
+	openaiClient.BaseURL = cfg.GetOpenAIBaseURL()
+	openaiClient.Retry.MaxRetries = cfg.GetMaxRetries()
+	openaiClient.Limiter = client.NewLimiter(cfg.GetOpenAIRateLimit(), cfg.GetOpenAIRateBurst())
+	openaiClient.Observer = requestObserver{}
+	if cfg.GetOpenAIStrictDecoding() {
+		openaiClient.Drift = client.NewDriftDetector(recordDrift)
+	}
+	if ttl := cfg.GetProjectCacheTTL(); ttl > 0 {
+		openaiClient.ProjectCache = client.NewProjectCache(ttl)
+	}
+	var apiClient client.APIClient = openaiClient
+	if threshold := cfg.GetBreakerFailureThreshold(); threshold > 0 {
+		apiClient = client.NewBreaker(openaiClient, client.BreakerConfig{
+			FailureThreshold: threshold,
+			OpenTimeout:      cfg.GetBreakerOpenTimeout(),
+			OnStateChange:    recordBreakerStateChange,
+		})
+	}
+	keyStore, err := newKeyStore(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("open key store: %w", err)
+	}
 	managementClient := management.NewManagement(
-		openaiClient,
+		apiClient,
 		cfg.GetExpiration(),
+		keyStore,
 	)
+	managementClient.SetTTLLimits(cfg.GetKeyTTLMin(), cfg.GetKeyTTLMax())
+	managementClient.SetLegacyOwners(cfg.GetAllowedUsers(), cfg.GetAllowedDomains())
 	oidcClient := oidc.NewOIDC(
 		cfg.GetDefaultProjectName(),
 		cfg.GetAllowedUsers(),
@@ -46,6 +87,7 @@ func NewServer(cfg *config.Config) (*Server, error) {
 		cfg.GetGoogleTokenIssuerURL(),
 		cfg.GetGoogleTokenJwksURL(),
 	)
+	oidcClient.SetHTTPClient(httpClient)
 
 	h := handler.NewHandler(
 		cfg.GetAllowedUsers(),
@@ -61,6 +103,9 @@ func NewServer(cfg *config.Config) (*Server, error) {
 	mux.HandleFunc("/", h.HandleRoot)
 	mux.HandleFunc("/oauth2/callback", h.HandleOAuthCallback)
 	mux.HandleFunc("/revoke", h.HandleRevoke)
+	if path := cfg.GetMetricsPath(); path != "" {
+		mux.Handle(path, expvar.Handler())
+	}
 
 	server := &http.Server{
 		Addr:              ":" + cfg.GetPort(),
@@ -74,10 +119,23 @@ func NewServer(cfg *config.Config) (*Server, error) {
 		handler:    h,
 		management: managementClient,
 		oidc:       oidcClient,
+		keyStore:   keyStore,
 		shutdown:   make(chan struct{}),
 	}, nil
 }
 
+// newKeyStore opens the store for issued keys selected by the configuration.
+func newKeyStore(cfg *config.Config) (store.Store, error) {
+	switch cfg.GetKeyStore() {
+	case "file":
+		return store.NewFileStore(cfg.GetKeyStorePath())
+	case "sqlite":
+		return store.OpenSQLite(context.Background(), cfg.GetKeyStorePath())
+	default:
+		return store.NewMemoryStore(), nil
+	}
+}
+
 // Start launches the HTTP server and sets up graceful shutdown handling.
 func (s *Server) Start() error {
 	// Graceful shutdown setup
@@ -110,6 +168,9 @@ func (s *Server) handleShutdown() {
 	if err := s.server.Shutdown(ctx); err != nil {
 		slog.Error("server shutdown error", "error", err)
 	}
+	if err := s.keyStore.Close(); err != nil {
+		slog.Error("close key store", "error", err)
+	}
 	close(s.shutdown)
 }
 
@@ -119,24 +180,38 @@ func (s *Server) startCleanupRoutine() {
 	defer ticker.Stop()
 
 	// Run cleanup immediately on startup
-	ctx := context.Background()
-	if err := s.management.CleanupAPIKey(ctx, s.oidc.GetDefaultProjectName()); err != nil {
-		slog.Error("failed to cleanup API keys", "error", err)
-	} else {
-		slog.Info("API key cleanup completed")
-	}
+	s.runCleanup(context.Background())
 
 	for {
 		select {
 		case <-ticker.C:
-			ctx := context.Background()
-			if err := s.management.CleanupAPIKey(ctx, s.oidc.GetDefaultProjectName()); err != nil {
-				slog.Error("failed to cleanup API keys", "error", err)
-			} else {
-				slog.Info("API key cleanup completed")
-			}
+			s.runCleanup(context.Background())
 		case <-s.shutdown:
 			return
 		}
 	}
 }
+
+// runCleanup runs API key cleanup once and logs the report. With CLEANUP_DRY_RUN, the report lists
+// the keys that would be deleted and nothing is deleted.
+func (s *Server) runCleanup(ctx context.Context) *management.CleanupReport {
+	report, err := s.management.CleanupAPIKey(ctx, s.oidc.GetDefaultProjectName(), s.config.GetCleanupDryRun())
+	if report == nil {
+		slog.Error("failed to cleanup API keys", "error", err)
+		return nil
+	}
+	msg := "deleted expired API key"
+	if report.DryRun {
+		msg = "would delete expired API key"
+	}
+	for _, entry := range report.Entries {
+		slog.Info(msg, "project_id", report.ProjectID, "service_account_id", entry.ServiceAccountID, "owner", entry.Owner, "age", entry.Age.Round(time.Second), "expires_at", entry.ExpiresAt, "reason", entry.Reason)
+	}
+	if err != nil {
+		// Keys deleted before the failure are logged above.
+		slog.Error("failed to cleanup API keys", "error", err, "keys", len(report.Entries))
+		return report
+	}
+	slog.Info("API key cleanup completed", "dry_run", report.DryRun, "keys", len(report.Entries))
+	return report
+}
@@ -0,0 +1,272 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hi120ki/monorepo/projects/openaikeyserver/config"
+	"github.com/hi120ki/monorepo/projects/openaikeyserver/openaitest"
+	"github.com/hi120ki/monorepo/projects/openaikeyserver/store"
+)
+
+func newTestConfig(srv *openaitest.Server) *config.Config {
+	return &config.Config{
+		AllowedUsers:        "user@example.com",
+		OpenAIManagementKey: openaitest.APIKey,
+		OpenAIBaseURL:       srv.BaseURL(),
+		ClientID:            "test-client-id",
+		ClientSecret:        "test-client-secret",
+		RedirectURI:         "http://localhost:8080/oauth2/callback",
+		DefaultProjectName:  "personal",
+		Port:                "8080",
+		Expiration:          3600,
+		CleanupInterval:     3600,
+		Timeout:             10,
+	}
+}
+
+func TestServer_Revoke(t *testing.T) {
+	// Create fake OpenAI Admin API
+	srv := openaitest.NewServer()
+	defer srv.Close()
+	project := srv.AddProject("personal")
+	expired := srv.AddServiceAccount(project.ID, "old@example.com", time.Now().Add(-2*time.Hour))
+	legacy := srv.AddServiceAccount(project.ID, "user@example.com", time.Now().Add(-2*time.Hour))
+	fresh := srv.AddServiceAccount(project.ID, "user@example.com", time.Now())
+	manual := srv.AddServiceAccount(project.ID, "production-workload", time.Now().Add(-2*time.Hour))
+
+	// Create server
+	s, err := NewServer(newTestConfig(srv))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// old@example.com is not an allowed user, so only its inventory record marks it as issued by this server
+	err = s.keyStore.Update(context.Background(), func(tx store.Tx) error {
+		return tx.Put(store.Key{
+			OwnerEmail:       "old@example.com",
+			ProjectID:        project.ID,
+			ServiceAccountID: expired.ID,
+			IssuedAt:         time.Now().Add(-2 * time.Hour),
+			ExpiresAt:        time.Now().Add(-1 * time.Hour),
+		})
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// Test /revoke
+	req := httptest.NewRequest(http.MethodGet, "/revoke", nil)
+	rec := httptest.NewRecorder()
+	s.server.Handler.ServeHTTP(rec, req)
+
+	// Verify result
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status code %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+	remaining := srv.ServiceAccounts(project.ID)
+	if len(remaining) != 2 || remaining[0].ID != fresh.ID || remaining[1].ID != manual.ID {
+		t.Errorf("Expected only %s and %s to remain after deleting %s and %s, got %+v", fresh.ID, manual.ID, expired.ID, legacy.ID, remaining)
+	}
+}
+
+func TestServer_CleanupDryRun(t *testing.T) {
+	// Create fake OpenAI Admin API
+	srv := openaitest.NewServer()
+	defer srv.Close()
+	project := srv.AddProject("personal")
+	expired := srv.AddServiceAccount(project.ID, "old@example.com", time.Now().Add(-2*time.Hour))
+
+	// Create server
+	cfg := newTestConfig(srv)
+	cfg.CleanupDryRun = true
+	s, err := NewServer(cfg)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	err = s.keyStore.Update(context.Background(), func(tx store.Tx) error {
+		return tx.Put(store.Key{
+			OwnerEmail:       "old@example.com",
+			ProjectID:        project.ID,
+			ServiceAccountID: expired.ID,
+			IssuedAt:         time.Now().Add(-2 * time.Hour),
+			ExpiresAt:        time.Now().Add(-1 * time.Hour),
+		})
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// Test runCleanup
+	report := s.runCleanup(context.Background())
+
+	// Verify result: the expired key is reported but not deleted
+	if report == nil || !report.DryRun || len(report.Entries) != 1 || report.Entries[0].ServiceAccountID != expired.ID || report.Entries[0].Owner != "old@example.com" {
+		t.Fatalf("Expected a dry-run report of %s, got %+v", expired.ID, report)
+	}
+	if n := len(srv.ServiceAccounts(project.ID)); n != 1 {
+		t.Errorf("Expected 1 service account to remain, got %d", n)
+	}
+}
+
+func TestServer_RevokeUpstreamError(t *testing.T) {
+	// Create fake OpenAI Admin API that rejects every request
+	srv := openaitest.NewServer()
+	defer srv.Close()
+	srv.InjectFault(openaitest.Fault{Path: "/projects", Status: http.StatusUnauthorized})
+
+	// Create server
+	s, err := NewServer(newTestConfig(srv))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// Test /revoke
+	req := httptest.NewRequest(http.MethodGet, "/revoke", nil)
+	rec := httptest.NewRecorder()
+	s.server.Handler.ServeHTTP(rec, req)
+
+	// Verify result
+	if rec.Code != http.StatusBadGateway {
+		t.Errorf("Expected status code %d, got %d", http.StatusBadGateway, rec.Code)
+	}
+}
+
+func TestNewServer_InvalidTransport(t *testing.T) {
+	// Create fake OpenAI Admin API
+	srv := openaitest.NewServer()
+	defer srv.Close()
+	cfg := newTestConfig(srv)
+	cfg.OutboundCAFiles = "/nonexistent/ca.pem"
+
+	// Test NewServer
+	if _, err := NewServer(cfg); err == nil {
+		t.Error("Expected error, got nil")
+	}
+}
+
+func TestServer_CircuitBreaker(t *testing.T) {
+	// Create fake OpenAI Admin API that is down
+	srv := openaitest.NewServer()
+	defer srv.Close()
+	srv.InjectFault(openaitest.Fault{Path: "/projects", Status: http.StatusBadGateway})
+	cfg := newTestConfig(srv)
+	cfg.BreakerFailureThreshold = 1
+	cfg.BreakerOpenTimeout = 60
+	cfg.MetricsPath = "/debug/vars"
+
+	// Create server
+	s, err := NewServer(cfg)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	serve := func(path string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		s.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		return rec
+	}
+
+	// The first failure opens the breaker
+	if rec := serve("/revoke"); rec.Code != http.StatusInternalServerError {
+		t.Errorf("Expected status code %d, got %d", http.StatusInternalServerError, rec.Code)
+	}
+	requests := len(srv.Requests())
+
+	// Later requests fail fast without reaching the API
+	if rec := serve("/revoke"); rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("Expected status code %d, got %d", http.StatusServiceUnavailable, rec.Code)
+	}
+	if n := len(srv.Requests()); n != requests {
+		t.Errorf("Expected no further API requests, got %d", n-requests)
+	}
+
+	// The breaker state is exported as a metric
+	rec := serve("/debug/vars")
+	if !strings.Contains(rec.Body.String(), `"circuit_state": "open"`) {
+		t.Errorf("Expected metrics to report an open circuit, got %s", rec.Body.String())
+	}
+}
+
+func TestServer_RequestMetrics(t *testing.T) {
+	// Create fake OpenAI Admin API
+	srv := openaitest.NewServer()
+	defer srv.Close()
+	srv.AddProject("personal")
+	cfg := newTestConfig(srv)
+	cfg.MetricsPath = "/debug/vars"
+
+	// Create server
+	s, err := NewServer(cfg)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	serve := func(path string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		s.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		return rec
+	}
+
+	// Test /revoke
+	if rec := serve("/revoke"); rec.Code != http.StatusOK {
+		t.Fatalf("Expected status code %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+
+	// Verify the requests are exported per templated endpoint
+	rec := serve("/debug/vars")
+	for _, endpoint := range []string{`"GET /projects"`, `"GET /projects/{id}/service_accounts"`} {
+		if !strings.Contains(rec.Body.String(), endpoint) {
+			t.Errorf("Expected metrics to contain %s, got %s", endpoint, rec.Body.String())
+		}
+	}
+}
+
+func TestServer_FileKeyStore(t *testing.T) {
+	// Create fake OpenAI Admin API
+	srv := openaitest.NewServer()
+	defer srv.Close()
+	srv.AddProject("personal")
+	cfg := newTestConfig(srv)
+	cfg.KeyStore = "file"
+	cfg.KeyStorePath = filepath.Join(t.TempDir(), "keys.json")
+
+	// Create server
+	s, err := NewServer(cfg)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// Test /revoke
+	rec := httptest.NewRecorder()
+	s.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/revoke", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status code %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+
+	// Verify the cleanup transaction was written to the key store
+	if _, err := os.Stat(cfg.KeyStorePath); err != nil {
+		t.Errorf("Expected key store file to exist, got %v", err)
+	}
+}
+
+func TestNewServer_InvalidKeyStore(t *testing.T) {
+	// Create fake OpenAI Admin API
+	srv := openaitest.NewServer()
+	defer srv.Close()
+	cfg := newTestConfig(srv)
+	cfg.KeyStore = "file"
+	cfg.KeyStorePath = filepath.Join(t.TempDir(), "keys.json")
+	if err := os.WriteFile(cfg.KeyStorePath, []byte("not json"), 0o600); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// Test NewServer
+	if _, err := NewServer(cfg); err == nil {
+		t.Error("Expected error, got nil")
+	}
+}
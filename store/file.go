@@ -0,0 +1,102 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+)
+
+// FileStore keeps keys in a JSON file. Every committed transaction rewrites the file atomically,
+// so a crash leaves either the previous or the new inventory on disk.
+type FileStore struct {
+	mu   sync.RWMutex
+	path string
+	keys map[string]Key
+}
+
+// NewFileStore opens the JSON file at path, creating the store empty if the file does not exist.
+func NewFileStore(path string) (*FileStore, error) {
+	s := &FileStore{
+		path: path,
+		keys: make(map[string]Key),
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read key store: %w", err)
+	}
+	var keys []Key
+	if err := json.Unmarshal(data, &keys); err != nil {
+		return nil, fmt.Errorf("unmarshal key store: %w", err)
+	}
+	for _, key := range keys {
+		s.keys[key.ServiceAccountID] = key
+	}
+	return s, nil
+}
+
+// Update runs fn on a copy of the keys and writes the copy to disk if fn succeeds.
+func (s *FileStore) Update(ctx context.Context, fn func(tx Tx) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	tx := &memoryTx{keys: maps.Clone(s.keys)}
+	if err := fn(tx); err != nil {
+		return err
+	}
+	if err := s.write(tx.keys); err != nil {
+		return err
+	}
+	s.keys = tx.keys
+	return nil
+}
+
+// View runs fn with read access to the keys.
+func (s *FileStore) View(ctx context.Context, fn func(tx Tx) error) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return fn(&memoryTx{keys: s.keys, readOnly: true})
+}
+
+// Close does nothing; every transaction is already on disk.
+func (s *FileStore) Close() error {
+	return nil
+}
+
+// write replaces the file with the given keys through a temporary file in the same directory.
+func (s *FileStore) write(keys map[string]Key) error {
+	list := slices.Collect(maps.Values(keys))
+	sortKeys(list)
+	data, err := json.MarshalIndent(list, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal key store: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("create key store: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		tmp.Close()
+		return fmt.Errorf("write key store: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("sync key store: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("close key store: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return fmt.Errorf("replace key store: %w", err)
+	}
+	return nil
+}
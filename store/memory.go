@@ -0,0 +1,79 @@
+package store
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"sync"
+)
+
+// MemoryStore keeps keys in memory. Its contents are lost when the process exits.
+type MemoryStore struct {
+	mu   sync.RWMutex
+	keys map[string]Key
+}
+
+// NewMemoryStore creates an empty in-memory store.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{keys: make(map[string]Key)}
+}
+
+// Update runs fn on a copy of the keys and keeps the copy if fn succeeds.
+func (s *MemoryStore) Update(ctx context.Context, fn func(tx Tx) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	tx := &memoryTx{keys: maps.Clone(s.keys)}
+	if err := fn(tx); err != nil {
+		return err
+	}
+	s.keys = tx.keys
+	return nil
+}
+
+// View runs fn with read access to the keys.
+func (s *MemoryStore) View(ctx context.Context, fn func(tx Tx) error) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return fn(&memoryTx{keys: s.keys, readOnly: true})
+}
+
+// Close does nothing for an in-memory store.
+func (s *MemoryStore) Close() error {
+	return nil
+}
+
+// memoryTx is a transaction over a map of keys indexed by service account ID.
+type memoryTx struct {
+	keys     map[string]Key
+	readOnly bool
+}
+
+func (tx *memoryTx) Get(serviceAccountID string) (*Key, bool, error) {
+	key, ok := tx.keys[serviceAccountID]
+	if !ok {
+		return nil, false, nil
+	}
+	return &key, true, nil
+}
+
+func (tx *memoryTx) List() (*[]Key, error) {
+	keys := slices.Collect(maps.Values(tx.keys))
+	sortKeys(keys)
+	return &keys, nil
+}
+
+func (tx *memoryTx) Put(key Key) error {
+	if tx.readOnly {
+		return ErrReadOnly
+	}
+	tx.keys[key.ServiceAccountID] = key
+	return nil
+}
+
+func (tx *memoryTx) Delete(serviceAccountID string) error {
+	if tx.readOnly {
+		return ErrReadOnly
+	}
+	delete(tx.keys, serviceAccountID)
+	return nil
+}
@@ -0,0 +1,151 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// schema creates the table used by SQLStore. Times are stored as Unix seconds.
+const schema = `CREATE TABLE IF NOT EXISTS api_keys (
+	service_account_id TEXT PRIMARY KEY,
+	owner_email        TEXT NOT NULL,
+	project_id         TEXT NOT NULL,
+	key_id             TEXT NOT NULL,
+	label              TEXT NOT NULL,
+	issued_at          INTEGER NOT NULL,
+	expires_at         INTEGER NOT NULL
+)`
+
+// SQLStore keeps keys in a SQL database, such as the embedded SQLite database opened by OpenSQLite.
+// Times are stored with second precision.
+type SQLStore struct {
+	db *sql.DB
+}
+
+// NewSQLStore creates a store on an open database and creates its table if needed.
+func NewSQLStore(ctx context.Context, db *sql.DB) (*SQLStore, error) {
+	if _, err := db.ExecContext(ctx, schema); err != nil {
+		return nil, fmt.Errorf("create key store schema: %w", err)
+	}
+	return &SQLStore{db: db}, nil
+}
+
+// Update runs fn in a database transaction that is committed if fn succeeds.
+func (s *SQLStore) Update(ctx context.Context, fn func(tx Tx) error) error {
+	return s.run(ctx, false, fn)
+}
+
+// View runs fn in a read-only database transaction.
+func (s *SQLStore) View(ctx context.Context, fn func(tx Tx) error) error {
+	return s.run(ctx, true, fn)
+}
+
+// Close closes the database.
+func (s *SQLStore) Close() error {
+	return s.db.Close()
+}
+
+// run executes fn in a transaction, rolling it back if fn fails.
+func (s *SQLStore) run(ctx context.Context, readOnly bool, fn func(tx Tx) error) error {
+	tx, err := s.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: readOnly})
+	if err != nil {
+		return fmt.Errorf("begin key store transaction: %w", err)
+	}
+	if err := fn(&sqlTx{ctx: ctx, tx: tx, readOnly: readOnly}); err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			return errors.Join(err, fmt.Errorf("roll back key store transaction: %w", rbErr))
+		}
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit key store transaction: %w", err)
+	}
+	return nil
+}
+
+// sqlTx is a transaction over the api_keys table.
+type sqlTx struct {
+	ctx      context.Context
+	tx       *sql.Tx
+	readOnly bool
+}
+
+const selectKeys = `SELECT service_account_id, owner_email, project_id, key_id, label, issued_at, expires_at FROM api_keys`
+
+func (tx *sqlTx) Get(serviceAccountID string) (*Key, bool, error) {
+	row := tx.tx.QueryRowContext(tx.ctx, selectKeys+` WHERE service_account_id = ?`, serviceAccountID)
+	key, err := scanKey(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("get key: %w", err)
+	}
+	return key, true, nil
+}
+
+func (tx *sqlTx) List() (*[]Key, error) {
+	rows, err := tx.tx.QueryContext(tx.ctx, selectKeys+` ORDER BY issued_at, service_account_id`)
+	if err != nil {
+		return nil, fmt.Errorf("list keys: %w", err)
+	}
+	defer rows.Close()
+	keys := []Key{}
+	for rows.Next() {
+		key, err := scanKey(rows)
+		if err != nil {
+			return nil, fmt.Errorf("list keys: %w", err)
+		}
+		keys = append(keys, *key)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("list keys: %w", err)
+	}
+	return &keys, nil
+}
+
+func (tx *sqlTx) Put(key Key) error {
+	if tx.readOnly {
+		return ErrReadOnly
+	}
+	_, err := tx.tx.ExecContext(tx.ctx, `INSERT INTO api_keys
+		(service_account_id, owner_email, project_id, key_id, label, issued_at, expires_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (service_account_id) DO UPDATE SET
+			owner_email = excluded.owner_email,
+			project_id = excluded.project_id,
+			key_id = excluded.key_id,
+			label = excluded.label,
+			issued_at = excluded.issued_at,
+			expires_at = excluded.expires_at`,
+		key.ServiceAccountID, key.OwnerEmail, key.ProjectID, key.KeyID, key.Label, key.IssuedAt.Unix(), key.ExpiresAt.Unix())
+	if err != nil {
+		return fmt.Errorf("put key: %w", err)
+	}
+	return nil
+}
+
+func (tx *sqlTx) Delete(serviceAccountID string) error {
+	if tx.readOnly {
+		return ErrReadOnly
+	}
+	if _, err := tx.tx.ExecContext(tx.ctx, `DELETE FROM api_keys WHERE service_account_id = ?`, serviceAccountID); err != nil {
+		return fmt.Errorf("delete key: %w", err)
+	}
+	return nil
+}
+
+// scanKey reads a key from a row selected with selectKeys.
+func scanKey(row interface{ Scan(dest ...any) error }) (*Key, error) {
+	var key Key
+	var issuedAt, expiresAt int64
+	if err := row.Scan(&key.ServiceAccountID, &key.OwnerEmail, &key.ProjectID, &key.KeyID, &key.Label, &issuedAt, &expiresAt); err != nil {
+		return nil, err
+	}
+	key.IssuedAt = time.Unix(issuedAt, 0)
+	key.ExpiresAt = time.Unix(expiresAt, 0)
+	return &key, nil
+}
@@ -0,0 +1,29 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/url"
+
+	_ "modernc.org/sqlite" // Registers the embedded "sqlite" driver
+)
+
+// OpenSQLite opens the embedded SQLite database at path, creating it if needed, and returns a store on it.
+// Write transactions take the database lock up front and wait for other writers instead of failing.
+func OpenSQLite(ctx context.Context, path string) (*SQLStore, error) {
+	query := url.Values{}
+	query.Add("_pragma", "busy_timeout(5000)")
+	query.Add("_pragma", "journal_mode(WAL)")
+	query.Set("_txlock", "immediate")
+	db, err := sql.Open("sqlite", "file:"+path+"?"+query.Encode())
+	if err != nil {
+		return nil, fmt.Errorf("open sqlite database: %w", err)
+	}
+	s, err := NewSQLStore(ctx, db)
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	return s, nil
+}
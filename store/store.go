@@ -0,0 +1,57 @@
+// Package store keeps the inventory of API keys issued by the server.
+package store
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"slices"
+	"time"
+)
+
+// ErrReadOnly is returned when a transaction started by View is used to write.
+var ErrReadOnly = errors.New("read-only transaction")
+
+// Key records an API key issued by the server.
+type Key struct {
+	OwnerEmail       string    `json:"owner_email"`        // Email of the user the key was issued to
+	ProjectID        string    `json:"project_id"`         // Project that owns the service account
+	ServiceAccountID string    `json:"service_account_id"` // Service account backing the key; identifies the record
+	KeyID            string    `json:"key_id"`             // ID of the service account's API key
+	Label            string    `json:"label"`              // Name of the service account
+	IssuedAt         time.Time `json:"issued_at"`          // When the key was issued
+	ExpiresAt        time.Time `json:"expires_at"`         // When the key is due for cleanup
+}
+
+// Store persists the inventory of issued keys.
+type Store interface {
+	// Update runs fn in a read-write transaction. Changes are committed when fn returns nil
+	// and discarded when it returns an error, which Update then returns unchanged.
+	Update(ctx context.Context, fn func(tx Tx) error) error
+	// View runs fn in a read-only transaction.
+	View(ctx context.Context, fn func(tx Tx) error) error
+	// Close releases the resources held by the store.
+	Close() error
+}
+
+// Tx reads and writes keys within a Store transaction.
+type Tx interface {
+	// Get returns the key backed by the service account, and whether it was found.
+	Get(serviceAccountID string) (*Key, bool, error)
+	// List returns every key ordered by issue time.
+	List() (*[]Key, error)
+	// Put adds a key or replaces the key with the same service account ID.
+	Put(key Key) error
+	// Delete removes the key backed by the service account. Deleting a missing key is not an error.
+	Delete(serviceAccountID string) error
+}
+
+// sortKeys orders keys by issue time, then by service account ID.
+func sortKeys(keys []Key) {
+	slices.SortFunc(keys, func(a, b Key) int {
+		if c := a.IssuedAt.Compare(b.IssuedAt); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.ServiceAccountID, b.ServiceAccountID)
+	})
+}
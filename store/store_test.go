@@ -0,0 +1,190 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// openStores returns one store of every kind, each backed by a fresh location in dir.
+func openStores(t *testing.T, dir string) map[string]Store {
+	t.Helper()
+	fileStore, err := NewFileStore(filepath.Join(dir, "keys.json"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	sqlStore, err := OpenSQLite(context.Background(), filepath.Join(dir, "keys.db"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	t.Cleanup(func() { sqlStore.Close() })
+	return map[string]Store{
+		"memory": NewMemoryStore(),
+		"file":   fileStore,
+		"sqlite": sqlStore,
+	}
+}
+
+func testKey(serviceAccountID string, issuedAt time.Time) Key {
+	return Key{
+		OwnerEmail:       "user@example.com",
+		ProjectID:        "proj_123",
+		ServiceAccountID: serviceAccountID,
+		KeyID:            "key_" + serviceAccountID,
+		Label:            "user@example.com",
+		IssuedAt:         issuedAt,
+		ExpiresAt:        issuedAt.Add(24 * time.Hour),
+	}
+}
+
+func TestStore_PutGetListDelete(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	for name, s := range openStores(t, t.TempDir()) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			first := testKey("sa_1", now)
+			second := testKey("sa_2", now.Add(time.Minute))
+
+			// Put keys out of order
+			err := s.Update(ctx, func(tx Tx) error {
+				if err := tx.Put(second); err != nil {
+					return err
+				}
+				return tx.Put(first)
+			})
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			// Read them back
+			err = s.View(ctx, func(tx Tx) error {
+				key, found, err := tx.Get("sa_2")
+				if err != nil {
+					return err
+				}
+				if !found || !reflect.DeepEqual(*key, second) {
+					t.Errorf("Expected key to be %+v, got %+v", second, key)
+				}
+				if _, found, err := tx.Get("sa_missing"); err != nil || found {
+					t.Errorf("Expected missing key not to be found, got found=%v err=%v", found, err)
+				}
+				keys, err := tx.List()
+				if err != nil {
+					return err
+				}
+				if expected := []Key{first, second}; !reflect.DeepEqual(*keys, expected) {
+					t.Errorf("Expected keys to be %+v, got %+v", expected, *keys)
+				}
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			// Delete one key, and a key that does not exist
+			err = s.Update(ctx, func(tx Tx) error {
+				if err := tx.Delete("sa_1"); err != nil {
+					return err
+				}
+				return tx.Delete("sa_missing")
+			})
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			assertServiceAccounts(t, s, "sa_2")
+		})
+	}
+}
+
+func TestStore_Rollback(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	for name, s := range openStores(t, t.TempDir()) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			if err := s.Update(ctx, func(tx Tx) error { return tx.Put(testKey("sa_1", now)) }); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			// A failing transaction leaves no trace
+			errFailed := errors.New("failed")
+			err := s.Update(ctx, func(tx Tx) error {
+				if err := tx.Put(testKey("sa_2", now)); err != nil {
+					return err
+				}
+				if err := tx.Delete("sa_1"); err != nil {
+					return err
+				}
+				return errFailed
+			})
+			if !errors.Is(err, errFailed) {
+				t.Fatalf("Expected error to be %v, got %v", errFailed, err)
+			}
+			assertServiceAccounts(t, s, "sa_1")
+		})
+	}
+}
+
+func TestStore_ViewIsReadOnly(t *testing.T) {
+	for name, s := range openStores(t, t.TempDir()) {
+		t.Run(name, func(t *testing.T) {
+			err := s.View(context.Background(), func(tx Tx) error {
+				return tx.Put(testKey("sa_1", time.Unix(1700000000, 0)))
+			})
+			if !errors.Is(err, ErrReadOnly) {
+				t.Errorf("Expected error to be %v, got %v", ErrReadOnly, err)
+			}
+			assertServiceAccounts(t, s)
+		})
+	}
+}
+
+func TestStore_Reopen(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	dir := t.TempDir()
+	for name, s := range openStores(t, dir) {
+		if name == "memory" {
+			continue
+		}
+		if err := s.Update(context.Background(), func(tx Tx) error { return tx.Put(testKey("sa_"+name, now)) }); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if err := s.Close(); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+
+	// Keys survive reopening the file and the database
+	for name, s := range openStores(t, dir) {
+		if name == "memory" {
+			continue
+		}
+		t.Run(name, func(t *testing.T) {
+			assertServiceAccounts(t, s, "sa_"+name)
+		})
+	}
+}
+
+// assertServiceAccounts checks that the store holds exactly the keys of the given service accounts, in order.
+func assertServiceAccounts(t *testing.T, s Store, expected ...string) {
+	t.Helper()
+	err := s.View(context.Background(), func(tx Tx) error {
+		keys, err := tx.List()
+		if err != nil {
+			return err
+		}
+		var ids []string
+		for _, key := range *keys {
+			ids = append(ids, key.ServiceAccountID)
+		}
+		if !reflect.DeepEqual(ids, expected) && (len(ids) != 0 || len(expected) != 0) {
+			t.Errorf("Expected service accounts %v, got %v", expected, ids)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,97 @@
+package transport
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+)
+
+// Config holds settings for outbound HTTP connections.
+type Config struct {
+	ProxyURL            string        // Proxy for all requests, credentials may be given as user info; empty uses the environment
+	CAFiles             []string      // PEM bundles trusted in addition to the system roots
+	ClientCertFile      string        // PEM client certificate for mutual TLS
+	ClientKeyFile       string        // PEM private key for ClientCertFile
+	MaxIdleConns        int           // Maximum idle connections across all hosts; zero means no limit
+	MaxIdleConnsPerHost int           // Maximum idle connections per host; zero uses the net/http default
+	MaxConnsPerHost     int           // Maximum connections per host; zero means no limit
+	IdleConnTimeout     time.Duration // How long idle connections are kept; zero means no limit
+	Timeout             time.Duration // Overall request timeout; zero means no timeout
+}
+
+// NewHTTPClient builds an HTTP client that applies the proxy, TLS and connection pool settings.
+func NewHTTPClient(cfg Config) (*http.Client, error) {
+	tr, err := NewTransport(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &http.Client{
+		Transport: tr,
+		Timeout:   cfg.Timeout,
+	}, nil
+}
+
+// NewTransport builds an HTTP transport that applies the proxy, TLS and connection pool settings.
+func NewTransport(cfg Config) (*http.Transport, error) {
+	tr := http.DefaultTransport.(*http.Transport).Clone()
+	tr.MaxIdleConns = cfg.MaxIdleConns
+	tr.MaxIdleConnsPerHost = cfg.MaxIdleConnsPerHost
+	tr.MaxConnsPerHost = cfg.MaxConnsPerHost
+	tr.IdleConnTimeout = cfg.IdleConnTimeout
+
+	if cfg.ProxyURL != "" {
+		proxyURL, err := url.Parse(cfg.ProxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("parse proxy url: %w", err)
+		}
+		if proxyURL.Scheme == "" || proxyURL.Host == "" {
+			return nil, fmt.Errorf("parse proxy url: scheme and host are required")
+		}
+		tr.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	tlsConfig, err := newTLSConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	tr.TLSClientConfig = tlsConfig
+	return tr, nil
+}
+
+// newTLSConfig builds the TLS configuration from the CA bundles and client certificate.
+func newTLSConfig(cfg Config) (*tls.Config, error) {
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+
+	if len(cfg.CAFiles) > 0 {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		for _, file := range cfg.CAFiles {
+			pem, err := os.ReadFile(file)
+			if err != nil {
+				return nil, fmt.Errorf("read ca bundle: %w", err)
+			}
+			if !pool.AppendCertsFromPEM(pem) {
+				return nil, fmt.Errorf("parse ca bundle %s: no certificates found", file)
+			}
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if (cfg.ClientCertFile == "") != (cfg.ClientKeyFile == "") {
+		return nil, fmt.Errorf("load client certificate: both certificate and key files are required")
+	}
+	if cfg.ClientCertFile != "" {
+		cert, err := tls.LoadX509KeyPair(cfg.ClientCertFile, cfg.ClientKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("load client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	return tlsConfig, nil
+}
@@ -0,0 +1,197 @@
+package transport
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// writeCertificate writes the server's certificate as a PEM file and returns its path.
+func writeCertificate(t *testing.T, srv *httptest.Server) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "ca.pem")
+	block := &pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw}
+	if err := os.WriteFile(path, pem.EncodeToMemory(block), 0o600); err != nil {
+		t.Fatalf("Failed to write certificate: %v", err)
+	}
+	return path
+}
+
+// generateClientCertificate writes a self-signed certificate and its key as PEM files.
+func generateClientCertificate(t *testing.T, commonName string) (string, string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("Failed to create certificate: %v", err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("Failed to marshal key: %v", err)
+	}
+
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "client.pem")
+	keyFile := filepath.Join(dir, "client-key.pem")
+	if err := os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600); err != nil {
+		t.Fatalf("Failed to write certificate: %v", err)
+	}
+	if err := os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600); err != nil {
+		t.Fatalf("Failed to write key: %v", err)
+	}
+	return certFile, keyFile
+}
+
+func TestNewHTTPClient_Defaults(t *testing.T) {
+	client, err := NewHTTPClient(Config{Timeout: 5 * time.Second, MaxIdleConns: 10, MaxConnsPerHost: 4})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if client.Timeout != 5*time.Second {
+		t.Errorf("Expected Timeout to be 5s, got %v", client.Timeout)
+	}
+	tr := client.Transport.(*http.Transport)
+	if tr.MaxIdleConns != 10 || tr.MaxConnsPerHost != 4 {
+		t.Errorf("Expected pool limits 10/4, got %d/%d", tr.MaxIdleConns, tr.MaxConnsPerHost)
+	}
+	if tr.Proxy == nil {
+		t.Error("Expected proxy to fall back to the environment")
+	}
+}
+
+func TestNewHTTPClient_CAFiles(t *testing.T) {
+	// Create TLS server with a self-signed certificate
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer srv.Close()
+
+	// Without the CA bundle the certificate is rejected
+	client, err := NewHTTPClient(Config{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := client.Get(srv.URL); err == nil {
+		t.Error("Expected certificate error, got nil")
+	}
+
+	// With the CA bundle the request succeeds
+	client, err = NewHTTPClient(Config{CAFiles: []string{writeCertificate(t, srv)}})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	resp, err := client.Get(srv.URL)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defer resp.Body.Close()
+	if body, _ := io.ReadAll(resp.Body); string(body) != "ok" {
+		t.Errorf("Expected body to be 'ok', got '%s'", body)
+	}
+}
+
+func TestNewHTTPClient_ClientCertificate(t *testing.T) {
+	// Create TLS server requiring client certificates
+	var subject string
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		subject = r.TLS.PeerCertificates[0].Subject.CommonName
+		_, _ = w.Write([]byte("ok"))
+	}))
+	srv.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert}
+	srv.StartTLS()
+	defer srv.Close()
+	certFile, keyFile := generateClientCertificate(t, "openaikeyserver")
+
+	// Test request with client certificate
+	client, err := NewHTTPClient(Config{
+		CAFiles:        []string{writeCertificate(t, srv)},
+		ClientCertFile: certFile,
+		ClientKeyFile:  keyFile,
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	resp, err := client.Get(srv.URL)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defer resp.Body.Close()
+
+	// Verify result
+	if subject != "openaikeyserver" {
+		t.Errorf("Expected client certificate for 'openaikeyserver', got '%s'", subject)
+	}
+}
+
+func TestNewHTTPClient_Proxy(t *testing.T) {
+	// Create proxy that checks credentials and answers on behalf of the target
+	var proxyAuth, target string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxyAuth = r.Header.Get("Proxy-Authorization")
+		target = r.URL.String()
+		_, _ = w.Write([]byte("proxied"))
+	}))
+	defer proxy.Close()
+
+	// Test request through the proxy
+	proxyURL := strings.Replace(proxy.URL, "http://", "http://user:secret@", 1)
+	client, err := NewHTTPClient(Config{ProxyURL: proxyURL})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	resp, err := client.Get("http://api.example.com/v1/organization/projects")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defer resp.Body.Close()
+
+	// Verify result
+	if target != "http://api.example.com/v1/organization/projects" {
+		t.Errorf("Expected proxy to receive the target URL, got '%s'", target)
+	}
+	if !strings.HasPrefix(proxyAuth, "Basic ") {
+		t.Errorf("Expected Proxy-Authorization header with basic credentials, got '%s'", proxyAuth)
+	}
+}
+
+func TestNewHTTPClient_InvalidConfig(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  Config
+	}{
+		{name: "Proxy without scheme", cfg: Config{ProxyURL: "proxy.example.com:3128"}},
+		{name: "Missing CA file", cfg: Config{CAFiles: []string{filepath.Join(t.TempDir(), "missing.pem")}}},
+		{name: "Certificate without key", cfg: Config{ClientCertFile: "client.pem"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewHTTPClient(tt.cfg); err == nil {
+				t.Error("Expected error, got nil")
+			}
+		})
+	}
+}